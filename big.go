@@ -0,0 +1,86 @@
+package govalidator
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+	bigRatType   = reflect.TypeOf(big.Rat{})
+)
+
+func isBigType(t reflect.Type) bool {
+	return t == bigIntType || t == bigFloatType || t == bigRatType
+}
+
+// asBigRat returns the parameter as an exact *big.Rat. It accepts
+// integers, decimals, exponents and fractions like "3/4".
+func asBigRat(param string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(param))
+	if !ok {
+		return nil, ErrBadParameter
+	}
+	return r, nil
+}
+
+// bigValue returns a pointer to the math/big number held by st, copying
+// it when st isn't addressable.
+func bigValue(st reflect.Value) interface{} {
+	if st.CanAddr() {
+		return st.Addr().Interface()
+	}
+	ptr := reflect.New(st.Type())
+	ptr.Elem().Set(st)
+	return ptr.Interface()
+}
+
+// bigSign returns the sign of the math/big number held by st.
+func bigSign(st reflect.Value) int {
+	switch n := bigValue(st).(type) {
+	case *big.Int:
+		return n.Sign()
+	case *big.Float:
+		return n.Sign()
+	case *big.Rat:
+		return n.Sign()
+	}
+	return 0
+}
+
+// compareBig compares the math/big number held by st with the number
+// given as parameter without going through float64, so no precision
+// is lost.
+func compareBig(st reflect.Value, param string) (int, error) {
+	p, err := asBigRat(param)
+	if err != nil {
+		return 0, err
+	}
+	switch n := bigValue(st).(type) {
+	case *big.Int:
+		return new(big.Rat).SetInt(n).Cmp(p), nil
+	case *big.Float:
+		if n.IsInf() {
+			return n.Sign(), nil
+		}
+		r, _ := n.Rat(nil)
+		return r.Cmp(p), nil
+	case *big.Rat:
+		return n.Cmp(p), nil
+	}
+	return 0, ErrUnsupported
+}
+
+// compareStruct compares the struct values the rules know how to
+// order, time.Time and the math/big numbers, with the given parameter.
+func compareStruct(st reflect.Value, param string) (int, error) {
+	switch {
+	case st.Type() == timeType:
+		return compareTime(st, param)
+	case isBigType(st.Type()):
+		return compareBig(st, param)
+	}
+	return 0, ErrUnsupported
+}
@@ -0,0 +1,39 @@
+package govalidator
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestBigNumbers(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	third := big.NewRat(1, 3)
+	d := New()
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{min, huge, "123456789012345678901234567890", nil},
+		{min, huge, "123456789012345678901234567891", ErrMin},
+		{max, huge, "1.2345678901234567890123456789e29", nil},
+		{max, big.NewFloat(0.1), "0.1", ErrMax}, // 0.1 has no exact float64 form
+		{max, third, "0.34", nil},
+		{min, *third, "1/3", nil},
+		{nonzero, new(big.Int), "", ErrZeroValue},
+		{nonzero, (*big.Int)(nil), "", ErrZeroValue},
+		{nonzero, huge, "", nil},
+		{nonzero, big.Rat{}, "", ErrZeroValue},
+		{d.enum, big.NewInt(2), "1, 2, 3", nil},
+		{d.enum, big.NewRat(5, 2), "2.5,3", nil},
+		{d.enum, big.NewInt(4), "1,2,3", ErrEnum},
+		{min, huge, "abc", ErrBadParameter},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); !errors.Is(err, tt.err) {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package govalidator
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultLookupTTL is how long the results of network lookups made by
+// the rules are cached.
+const defaultLookupTTL = 5 * time.Minute
+
+// defaultLookupEntries bounds the number of values cached by each
+// lookup cache, whose keys come from the validated input.
+const defaultLookupEntries = 10000
+
+// defaultLookupTimeout bounds each network lookup made by the rules.
+const defaultLookupTimeout = 5 * time.Second
+
+type cacheEntry struct {
+	val     interface{}
+	err     error
+	expires time.Time
+}
+
+// lookupCache caches the outcome of lookups, such as DNS queries, made
+// by rules. It holds at most max entries: once full, expired entries are
+// swept and, if that isn't enough, random ones evicted. It is safe for
+// concurrent use.
+type lookupCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	max     int
+	entries map[string]cacheEntry
+}
+
+func newLookupCache(ttl time.Duration, max int) *lookupCache {
+	return &lookupCache{ttl: ttl, max: max, entries: map[string]cacheEntry{}}
+}
+
+func (c *lookupCache) get(key string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return e, false
+	}
+	if timeNow().After(e.expires) {
+		delete(c.entries, key)
+		return e, false
+	}
+	return e, true
+}
+
+func (c *lookupCache) set(key string, val interface{}, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := timeNow()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.max {
+		for k, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		// map iteration order is random enough for eviction
+		for k := range c.entries {
+			if len(c.entries) < c.max {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cacheEntry{val: val, err: err, expires: now.Add(c.ttl)}
+}
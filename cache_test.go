@@ -0,0 +1,40 @@
+package govalidator
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLookupCacheBound(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	c := newLookupCache(time.Minute, 3)
+	for i := 0; i < 10; i++ {
+		c.set(strconv.Itoa(i), true, nil)
+		if len(c.entries) > 3 {
+			t.Fatalf("set(%d): %d entries, want at most 3", i, len(c.entries))
+		}
+	}
+	if _, ok := c.get("9"); !ok {
+		t.Errorf("get(9): newest entry was evicted")
+	}
+
+	// expired entries are swept before any live one is evicted
+	c = newLookupCache(time.Minute, 3)
+	c.set("a", true, nil)
+	c.set("b", true, nil)
+	now = now.Add(2 * time.Minute)
+	c.set("c", true, nil)
+	c.set("d", true, nil)
+	if len(c.entries) != 2 {
+		t.Errorf("got %d entries, want 2", len(c.entries))
+	}
+	for _, k := range []string{"c", "d"} {
+		if _, ok := c.get(k); !ok {
+			t.Errorf("get(%s): live entry was evicted", k)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package govalidator
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrWeekday       = errors.New("not a weekday")
+	ErrWeekend       = errors.New("not a weekend day")
+	ErrBusinessDay   = errors.New("not a business day")
+	ErrBusinessHours = errors.New("outside business hours")
+)
+
+// HolidayCalendar tells whether a date is a holiday. The date is given in
+// the location of the business hours, if any, or of the time validated.
+type HolidayCalendar interface {
+	IsHoliday(date time.Time) bool
+}
+
+// HolidayCalendarFunc adapts a function to a HolidayCalendar.
+type HolidayCalendarFunc func(date time.Time) bool
+
+func (f HolidayCalendarFunc) IsHoliday(date time.Time) bool {
+	return f(date)
+}
+
+// HolidaySet is a fixed HolidayCalendar of dates written as "2006-01-02".
+type HolidaySet map[string]struct{}
+
+func NewHolidaySet(dates ...string) HolidaySet {
+	s := make(HolidaySet, len(dates))
+	for _, date := range dates {
+		s[date] = struct{}{}
+	}
+	return s
+}
+
+func (s HolidaySet) IsHoliday(date time.Time) bool {
+	_, ok := s[date.Format(time.DateOnly)]
+	return ok
+}
+
+// OpeningHours is the daily opening period of a business as offsets from
+// midnight, e.g. {9 * time.Hour, 17*time.Hour + 30*time.Minute}. Close
+// is exclusive and may be 24h, periods don't span midnight.
+type OpeningHours struct {
+	Open, Close time.Duration
+}
+
+// defaultBusinessHours are 9:00 to 17:00, Monday to Friday.
+func defaultBusinessHours() map[time.Weekday]OpeningHours {
+	hours := map[time.Weekday]OpeningHours{}
+	for day := time.Monday; day <= time.Friday; day++ {
+		hours[day] = OpeningHours{9 * time.Hour, 17 * time.Hour}
+	}
+	return hours
+}
+
+func SetBusinessHours(loc *time.Location, hours map[time.Weekday]OpeningHours) {
+	defaultValidator.SetBusinessHours(loc, hours)
+}
+
+// SetBusinessHours sets the opening hours of each day used by businessday
+// and businesshours, days missing from hours are closed. Times are taken
+// in loc, or in their own location if loc is nil. The default is 9:00 to
+// 17:00, Monday to Friday, in the location of the times.
+func (d *Validator) SetBusinessHours(loc *time.Location, hours map[time.Weekday]OpeningHours) {
+	d.businessLocation = loc
+	d.businessHours = make(map[time.Weekday]OpeningHours, len(hours))
+	for day, h := range hours {
+		d.businessHours[day] = h
+	}
+}
+
+func SetHolidayCalendar(c HolidayCalendar) {
+	defaultValidator.SetHolidayCalendar(c)
+}
+
+// SetHolidayCalendar sets the holidays on which businessday and
+// businesshours fail. A nil c removes them.
+func (d *Validator) SetHolidayCalendar(c HolidayCalendar) {
+	d.holidays = c
+}
+
+// inLocation returns the time held by v in the location named by param,
+// an IANA time zone such as "Europe/Paris", or its own if param is empty.
+func inLocation(v interface{}, param string) (time.Time, bool, error) {
+	var loc *time.Location
+	if param != "" {
+		var err error
+		if loc, err = time.LoadLocation(param); err != nil {
+			return time.Time{}, false, ErrBadParameter
+		}
+	}
+	t, ok, err := timeValue(v)
+	if !ok {
+		return t, false, err
+	}
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return t, true, nil
+}
+
+// weekday tests whether a time, or a timestamp string, falls on Monday
+// to Friday. The optional parameter is the time zone to take it in, e.g.
+// weekday=America/New_York.
+func weekday(v interface{}, param string) error {
+	t, ok, err := inLocation(v, param)
+	if !ok {
+		return err
+	}
+	if day := t.Weekday(); day == time.Saturday || day == time.Sunday {
+		return ErrWeekday
+	}
+	return nil
+}
+
+// weekend tests whether a time falls on Saturday or Sunday. It takes the
+// parameter of weekday.
+func weekend(v interface{}, param string) error {
+	t, ok, err := inLocation(v, param)
+	if !ok {
+		return err
+	}
+	if day := t.Weekday(); day != time.Saturday && day != time.Sunday {
+		return ErrWeekend
+	}
+	return nil
+}
+
+// businessTime returns the time held by v in the location of the
+// business hours and its opening hours, open is false if the business is
+// closed that day.
+func (d *Validator) businessTime(v interface{}) (t time.Time, hours OpeningHours, open, ok bool, err error) {
+	if t, ok, err = timeValue(v); !ok {
+		return
+	}
+	if d.businessLocation != nil {
+		t = t.In(d.businessLocation)
+	}
+	hours, open = d.businessHours[t.Weekday()]
+	if open && d.holidays != nil && d.holidays.IsHoliday(t) {
+		open = false
+	}
+	return
+}
+
+// businessday tests whether a time falls on a day with business hours
+// that isn't a holiday, see SetBusinessHours and SetHolidayCalendar.
+func (d *Validator) businessday(v interface{}, param string) error {
+	_, _, open, ok, err := d.businessTime(v)
+	if !ok {
+		return err
+	}
+	if !open {
+		return ErrBusinessDay
+	}
+	return nil
+}
+
+// businesshours tests whether a time falls within the business hours of
+// a business day.
+func (d *Validator) businesshours(v interface{}, param string) error {
+	t, hours, open, ok, err := d.businessTime(v)
+	if !ok {
+		return err
+	}
+	// wall clock time, unaffected by daylight saving changes
+	h, m, sec := t.Clock()
+	sinceMidnight := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute +
+		time.Duration(sec)*time.Second + time.Duration(t.Nanosecond())
+	if !open || sinceMidnight < hours.Open || sinceMidnight >= hours.Close {
+		return ErrBusinessHours
+	}
+	return nil
+}
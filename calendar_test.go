@@ -0,0 +1,113 @@
+package govalidator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeekdayWeekend(t *testing.T) {
+	friday := time.Date(2024, 5, 3, 23, 30, 0, 0, time.UTC)
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{weekday, friday, "", nil},
+		{weekend, friday, "", ErrWeekend},
+		// already Saturday in Paris
+		{weekday, friday, "Europe/Paris", ErrWeekday},
+		{weekend, friday, "Europe/Paris", nil},
+		{weekday, "2024-05-04", "", ErrWeekday},
+		{weekend, "2024-05-05T10:00:00+02:00", "", nil},
+		{weekday, &friday, "", nil},
+		{weekday, "", "", nil},
+		{weekday, (*time.Time)(nil), "", nil},
+		{weekday, nil, "", nil},
+		{weekend, nil, "", nil},
+		{weekday, "next friday", "", ErrDateTime},
+		{weekday, friday, "Mars/Olympus", ErrBadParameter},
+		{weekday, 5, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+type Appointment struct {
+	Day  string    `valid:"businessday"`
+	Slot time.Time `valid:"businesshours"`
+}
+
+func TestBusinessCalendar(t *testing.T) {
+	d := New()
+	monday := time.Date(2024, 12, 23, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		fn  ValidateFunc
+		v   interface{}
+		err error
+	}{
+		{d.businessday, monday, nil},
+		{d.businessday, "2024-12-25", nil},
+		{d.businessday, "2024-12-28", ErrBusinessDay},
+		{d.businesshours, monday.Add(9 * time.Hour), nil},
+		{d.businesshours, monday.Add(17*time.Hour - time.Nanosecond), nil},
+		{d.businesshours, monday.Add(17 * time.Hour), ErrBusinessHours},
+		{d.businesshours, monday.Add(8*time.Hour + 59*time.Minute), ErrBusinessHours},
+		{d.businesshours, "2024-12-28T10:00:00Z", ErrBusinessHours},
+		{d.businesshours, "", nil},
+		{d.businesshours, nil, nil},
+		{d.businessday, nil, nil},
+		{d.businessday, 1, ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, ""); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip(err)
+	}
+	d.SetBusinessHours(paris, map[time.Weekday]OpeningHours{
+		time.Monday:   {9 * time.Hour, 18 * time.Hour},
+		time.Tuesday:  {9 * time.Hour, 18 * time.Hour},
+		time.Saturday: {10 * time.Hour, 13 * time.Hour},
+		time.Sunday:   {10 * time.Hour, 13 * time.Hour},
+	})
+	d.SetHolidayCalendar(NewHolidaySet("2024-12-24", "2024-12-25"))
+	tests = []struct {
+		fn  ValidateFunc
+		v   interface{}
+		err error
+	}{
+		{d.businessday, "2024-12-28", nil},
+		{d.businessday, "2024-12-24", ErrBusinessDay},
+		{d.businessday, "2024-12-25", ErrBusinessDay},
+		{d.businessday, "2024-12-26", ErrBusinessDay},
+		// 17:30 in Paris
+		{d.businesshours, "2024-12-23T16:30:00Z", nil},
+		{d.businesshours, "2024-12-23T17:30:00Z", ErrBusinessHours},
+		{d.businesshours, "2024-12-28T12:59:00+01:00", nil},
+		{d.businesshours, "2024-12-24T10:00:00+01:00", ErrBusinessHours},
+		// wall clock time on the day clocks go forward
+		{d.businesshours, "2024-03-31T12:30:00+02:00", nil},
+		{d.businesshours, "2024-03-31T13:30:00+02:00", ErrBusinessHours},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, ""); err != tt.err {
+			t.Errorf("paris %d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+
+	d.SetHolidayCalendar(HolidayCalendarFunc(func(date time.Time) bool {
+		return date.Month() == time.December && date.Day() == 23
+	}))
+	resp, _ := d.Validate(Appointment{Day: "2024-12-23", Slot: time.Date(2024, 12, 24, 8, 0, 0, 0, time.UTC)})
+	if len(resp) != 1 || resp["Day"] != ErrBusinessDay {
+		t.Fatalf("resp: %v", resp)
+	}
+}
@@ -0,0 +1,664 @@
+package govalidator
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	ErrDuplicate = errors.New("contains duplicates")
+	ErrUnsorted  = errors.New("not sorted")
+	ErrNilItem   = errors.New("is nil")
+	ErrTotalMax  = errors.New("too many elements in total")
+
+	ErrNotSubset   = errors.New("not among the allowed values")
+	ErrNotDisjoint = errors.New("among the excluded values")
+
+	ErrSum    = errors.New("sum not equal to the given value")
+	ErrSumMin = errors.New("sum less than min")
+	ErrSumMax = errors.New("sum greater than max")
+	ErrAvgMin = errors.New("average less than min")
+	ErrAvgMax = errors.New("average greater than max")
+
+	ErrMissingKey    = errors.New("missing key")
+	ErrUnexpectedKey = errors.New("unexpected key")
+)
+
+// DuplicateError reports the indexes of the elements of a slice that
+// repeat an earlier one. It unwraps to ErrDuplicate.
+type DuplicateError struct {
+	Indexes []int
+}
+
+func (e *DuplicateError) Error() string {
+	idx := make([]string, len(e.Indexes))
+	for i, n := range e.Indexes {
+		idx[i] = strconv.Itoa(n)
+	}
+	return fmt.Sprintf("%v at indexes %s", ErrDuplicate, strings.Join(idx, ", "))
+}
+
+func (e *DuplicateError) Unwrap() error {
+	return ErrDuplicate
+}
+
+// ElementError reports the index and the value, nil for nil elements,
+// of the first element of a slice that fails a per-element rule such as
+// eachmin. It unwraps to the error of the element.
+type ElementError struct {
+	Index int
+	Value interface{}
+	Err   error
+}
+
+func (e *ElementError) Error() string {
+	if e.Value == nil {
+		return fmt.Sprintf("element %d: %v", e.Index, e.Err)
+	}
+	return fmt.Sprintf("element %d (%v): %v", e.Index, e.Value, e.Err)
+}
+
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+// KeyError reports the first key of a map missing from it or not
+// allowed in it. It unwraps to ErrMissingKey or ErrUnexpectedKey.
+type KeyError struct {
+	Key string
+	Err error
+}
+
+func (e *KeyError) Error() string {
+	return fmt.Sprintf("%v %q", e.Err, e.Key)
+}
+
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
+
+// sliceValue returns the slice or array held by v, through pointers.
+// ok is false for nil values, which pass, and err is set for values
+// of other kinds.
+func sliceValue(v interface{}) (st reflect.Value, ok bool, err error) {
+	st = indirect(reflect.ValueOf(v))
+	switch st.Kind() {
+	case reflect.Slice, reflect.Array:
+		return st, true, nil
+	case reflect.Ptr, reflect.Invalid:
+		return st, false, nil
+	}
+	return st, false, ErrUnsupported
+}
+
+// elemKey returns the element, or its field named field when set, an
+// element is compared by. ok is false for nil elements.
+func elemKey(elem reflect.Value, field string) (key reflect.Value, ok bool, err error) {
+	elem = indirect(elem)
+	if elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface || !elem.IsValid() {
+		return elem, false, nil
+	}
+	if field != "" {
+		if elem.Kind() != reflect.Struct {
+			return elem, false, ErrUnsupported
+		}
+		f, ok := elem.Type().FieldByName(field)
+		if !ok || f.PkgPath != "" {
+			return reflect.Value{}, false, ErrBadParameter
+		}
+		elem = indirect(elem.FieldByIndex(f.Index))
+		if elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface {
+			return elem, false, nil
+		}
+	}
+	return elem, true, nil
+}
+
+// unique tests whether the elements of a slice or array are distinct,
+// or for structs, given the name of a field, e.g. unique=Email, the
+// values of that field. Strings can be normalized first with "trim",
+// removing the whitespace around them, and "fold", ignoring case, or
+// both with "ci" the way enumci compares them, e.g. unique=Email,ci.
+// Nil elements are skipped. The error is a *DuplicateError listing the
+// offending indexes.
+func unique(v interface{}, param string) error {
+	st, ok, err := sliceValue(v)
+	if !ok {
+		return err
+	}
+	var field string
+	var trim, fold bool
+	for _, p := range SplitParams(param) {
+		switch {
+		case p == "ci":
+			trim, fold = true, true
+		case p == "trim":
+			trim = true
+		case p == "fold":
+			fold = true
+		case field == "":
+			field = p
+		default:
+			return ErrBadParameter
+		}
+	}
+	seen := make(map[interface{}]bool, st.Len())
+	var dups []int
+	for i := 0; i < st.Len(); i++ {
+		key, ok, err := elemKey(st.Index(i), field)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if !key.Type().Comparable() {
+			return ErrUnsupported
+		}
+		k := key.Interface()
+		if trim || fold {
+			if key.Kind() != reflect.String {
+				return ErrUnsupported
+			}
+			s := key.String()
+			if trim {
+				s = strings.TrimSpace(s)
+			}
+			if fold {
+				s = foldCase(s)
+			}
+			k = s
+		}
+		if seen[k] {
+			dups = append(dups, i)
+		}
+		seen[k] = true
+	}
+	if len(dups) > 0 {
+		return &DuplicateError{Indexes: dups}
+	}
+	return nil
+}
+
+// foldCase maps every rune of s to the smallest rune it case folds to,
+// so that strings.EqualFold strings fold to the same string.
+func foldCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		min := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < min {
+				min = f
+			}
+		}
+		return min
+	}, s)
+}
+
+// compareElems orders two elements of a slice: numbers, strings,
+// time.Time and the math/big numbers, both of the same type.
+func compareElems(a, b reflect.Value) (int, error) {
+	if a.Type() != b.Type() {
+		return 0, ErrUnsupported
+	}
+	switch a.Kind() {
+	case reflect.String:
+		return strings.Compare(a.String(), b.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float()), nil
+	case reflect.Struct:
+		if a.Type() == timeType {
+			return a.Interface().(time.Time).Compare(b.Interface().(time.Time)), nil
+		}
+		switch x := bigValue(a).(type) {
+		case *big.Int:
+			return x.Cmp(bigValue(b).(*big.Int)), nil
+		case *big.Float:
+			return x.Cmp(bigValue(b).(*big.Float)), nil
+		case *big.Rat:
+			return x.Cmp(bigValue(b).(*big.Rat)), nil
+		}
+	}
+	return 0, ErrUnsupported
+}
+
+// sorted tests whether the elements of a slice or array are in
+// ascending order, equal neighbours allowed, or in descending order
+// with the "desc" parameter. For structs the name of the field to
+// order by is given as well, e.g. sorted=Price,asc. Nil elements are
+// skipped.
+func sorted(v interface{}, param string) error {
+	st, ok, err := sliceValue(v)
+	if !ok {
+		return err
+	}
+	var field string
+	desc, dirSet := false, false
+	for _, p := range SplitParams(param) {
+		switch {
+		case (p == "asc" || p == "desc") && !dirSet:
+			desc, dirSet = p == "desc", true
+		case p != "asc" && p != "desc" && field == "":
+			field = p
+		default:
+			return ErrBadParameter
+		}
+	}
+	var prev reflect.Value
+	for i := 0; i < st.Len(); i++ {
+		key, ok, err := elemKey(st.Index(i), field)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if prev.IsValid() {
+			c, err := compareElems(prev, key)
+			if err != nil {
+				return err
+			}
+			if desc && c < 0 || !desc && c > 0 {
+				return ErrUnsorted
+			}
+		}
+		prev = key
+	}
+	return nil
+}
+
+// eachElem applies fn to every element of a slice or array, stopping at
+// the first one failing it.
+func eachElem(v interface{}, param string, fn ValidateFunc) error {
+	st, ok, err := sliceValue(v)
+	if !ok {
+		return err
+	}
+	for i := 0; i < st.Len(); i++ {
+		elem := st.Index(i)
+		err := fn(elem.Interface(), param)
+		switch err {
+		case nil:
+		case ErrBadParameter, ErrUnsupported:
+			return err
+		default:
+			return &ElementError{Index: i, Value: elemValue(elem), Err: err}
+		}
+	}
+	return nil
+}
+
+// elemValue returns the value an element holds through pointers, nil
+// for nil elements.
+func elemValue(elem reflect.Value) interface{} {
+	elem = indirect(elem)
+	if elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface {
+		return nil
+	}
+	return valueInterface(elem)
+}
+
+// eachmin is min applied to every element of a slice, a shortcut for
+// "dive;min=N" reporting the first failing element as an *ElementError.
+func (d *Validator) eachmin(v interface{}, param string) error {
+	return eachElem(v, param, d.min)
+}
+
+// eachmax is max applied to every element of a slice.
+func (d *Validator) eachmax(v interface{}, param string) error {
+	return eachElem(v, param, d.max)
+}
+
+// eachlen is len applied to every element of a slice.
+func (d *Validator) eachlen(v interface{}, param string) error {
+	return eachElem(v, param, d.length)
+}
+
+// nonilitems tests whether a slice or array of pointers or interfaces
+// holds no nil element, including interfaces holding nil pointers. The
+// first one is reported as an *ElementError.
+func nonilitems(v interface{}, param string) error {
+	st, ok, err := sliceValue(v)
+	if !ok {
+		return err
+	}
+	if k := st.Type().Elem().Kind(); k != reflect.Ptr && k != reflect.Interface {
+		return ErrUnsupported
+	}
+	for i := 0; i < st.Len(); i++ {
+		elem := indirect(st.Index(i))
+		if !elem.IsValid() || (elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface) && elem.IsNil() {
+			return &ElementError{Index: i, Err: ErrNilItem}
+		}
+	}
+	return nil
+}
+
+// mapKeys returns the keys of the map held by v, through pointers, as
+// strings, in a stable order. Maps keyed by other types than strings
+// and integers are unsupported. ok is false for nil values.
+func mapKeys(v interface{}) (keys []string, ok bool, err error) {
+	st := indirect(reflect.ValueOf(v))
+	switch st.Kind() {
+	case reflect.Map:
+		if st.IsNil() {
+			return nil, false, nil
+		}
+	case reflect.Ptr, reflect.Invalid:
+		return nil, false, nil
+	default:
+		return nil, false, ErrUnsupported
+	}
+	for _, key := range sortedKeys(st) {
+		switch key.Kind() {
+		case reflect.String:
+			keys = append(keys, key.String())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			keys = append(keys, strconv.FormatInt(key.Int(), 10))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			keys = append(keys, strconv.FormatUint(key.Uint(), 10))
+		default:
+			return nil, false, ErrUnsupported
+		}
+	}
+	return keys, true, nil
+}
+
+// haskeys tests whether a map holds all the keys given as parameters,
+// e.g. haskeys=name,version, reporting the first missing one as a
+// *KeyError. An empty map is checked too, a nil one passes.
+func haskeys(v interface{}, param string) error {
+	want := SplitParams(param)
+	if len(want) == 0 {
+		return ErrBadParameter
+	}
+	keys, ok, err := mapKeys(v)
+	if !ok {
+		return err
+	}
+	for _, w := range want {
+		if !inStringSlice(w, keys) {
+			return &KeyError{Key: w, Err: ErrMissingKey}
+		}
+	}
+	return nil
+}
+
+// onlykeys tests whether a map holds no other keys than the ones given
+// as parameters, reporting the first other one as a *KeyError.
+func onlykeys(v interface{}, param string) error {
+	allowed := SplitParams(param)
+	if len(allowed) == 0 {
+		return ErrBadParameter
+	}
+	keys, ok, err := mapKeys(v)
+	if !ok {
+		return err
+	}
+	for _, k := range keys {
+		if !inStringSlice(k, allowed) {
+			return &KeyError{Key: k, Err: ErrUnexpectedKey}
+		}
+	}
+	return nil
+}
+
+// elemSet returns the elements of the slice or array held by v, through
+// pointers, as a set. Nil elements are left out.
+func elemSet(v interface{}) (map[interface{}]bool, error) {
+	st, ok, err := sliceValue(v)
+	if !ok {
+		return nil, err
+	}
+	set := make(map[interface{}]bool, st.Len())
+	for i := 0; i < st.Len(); i++ {
+		if elem := elemValue(st.Index(i)); elem != nil {
+			if !reflect.TypeOf(elem).Comparable() {
+				return nil, ErrUnsupported
+			}
+			set[elem] = true
+		}
+	}
+	return set, nil
+}
+
+// memberCheck reports the first element of the slice v whose presence
+// in the slice other differs from want as an *ElementError.
+func memberCheck(v, other interface{}, want bool, errFail error) error {
+	st, ok, err := sliceValue(v)
+	if !ok {
+		return err
+	}
+	set, err := elemSet(other)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < st.Len(); i++ {
+		elem := elemValue(st.Index(i))
+		if elem == nil {
+			continue
+		}
+		if !reflect.TypeOf(elem).Comparable() {
+			return ErrUnsupported
+		}
+		if set[elem] != want {
+			return &ElementError{Index: i, Value: elem, Err: errFail}
+		}
+	}
+	return nil
+}
+
+// subsetof tests whether every element of a slice is also an element of
+// the slice field of the same struct named as parameter, e.g.
+// subsetof=AllowedTags, reporting the first other one as an
+// *ElementError. A nil or empty other field allows nothing.
+func subsetof(v, other interface{}) error {
+	return memberCheck(v, other, true, ErrNotSubset)
+}
+
+// disjointwith tests whether no element of a slice is an element of the
+// slice field of the same struct named as parameter, e.g.
+// disjointwith=BlockedTags.
+func disjointwith(v, other interface{}) error {
+	return memberCheck(v, other, false, ErrNotDisjoint)
+}
+
+// leafCount returns the number of elements of the nested slices, arrays
+// and maps held by v that aren't collections themselves. Byte slices
+// and arrays, such as a [16]byte UUID, count as single elements, like
+// strings.
+func leafCount(v reflect.Value) int {
+	v = indirect(v)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return 1
+		}
+	case reflect.Map:
+		n := 0
+		for iter := v.MapRange(); iter.Next(); {
+			n += leafCount(iter.Value())
+		}
+		return n
+	case reflect.Ptr, reflect.Interface:
+		return 0
+	default:
+		return 1
+	}
+	n := 0
+	for i := 0; i < v.Len(); i++ {
+		n += leafCount(v.Index(i))
+	}
+	return n
+}
+
+// totalmax tests whether nested collections such as a
+// map[string][]string or a [][]int hold at most the given number of
+// elements in total, counting those of the innermost collections. Nil
+// elements aren't counted.
+func totalmax(v interface{}, param string) error {
+	p, err := asInt(param)
+	if err != nil || p < 0 {
+		return ErrBadParameter
+	}
+	st := indirect(reflect.ValueOf(v))
+	switch st.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+	case reflect.Ptr, reflect.Invalid:
+		return nil
+	default:
+		return ErrUnsupported
+	}
+	if int64(leafCount(st)) > p {
+		return ErrTotalMax
+	}
+	return nil
+}
+
+// sumTolerance is the relative difference below which a total of floats
+// equals a parameter, so that 33.3, 33.3 and 33.4 sum to 100.
+const sumTolerance = 1e-9
+
+// sumOf returns the exact total of the numeric elements of a slice or
+// array held by v and their number, nil elements left out. approx is
+// set when some of them are floats. total is nil for nil values.
+func sumOf(v interface{}) (total *big.Rat, n int, approx bool, err error) {
+	st, ok, err := sliceValue(v)
+	if !ok {
+		return nil, 0, false, err
+	}
+	total = new(big.Rat)
+	for i := 0; i < st.Len(); i++ {
+		elem := indirect(st.Index(i))
+		var x big.Rat
+		switch elem.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			x.SetInt64(elem.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			x.SetUint64(elem.Uint())
+		case reflect.Float32, reflect.Float64:
+			f := elem.Float()
+			if math.IsNaN(f) || math.IsInf(f, 0) {
+				return nil, 0, false, ErrUnsupported
+			}
+			x.SetFloat64(f)
+			approx = true
+		case reflect.Ptr, reflect.Interface:
+			continue
+		default:
+			return nil, 0, false, ErrUnsupported
+		}
+		total.Add(total, &x)
+		n++
+	}
+	return total, n, approx, nil
+}
+
+// compareSum compares a total with a parameter, treating them as equal
+// within sumTolerance when approx is set.
+func compareSum(total, p *big.Rat, approx bool) int {
+	diff := new(big.Rat).Sub(total, p)
+	if approx {
+		d, _ := diff.Float64()
+		scale, _ := p.Float64()
+		if math.Abs(d) <= sumTolerance*math.Max(1, math.Abs(scale)) {
+			return 0
+		}
+	}
+	return diff.Sign()
+}
+
+// compareTotal compares the total of a numeric slice, or its average
+// when avg is set, with the parameter. ok is false for nil values and
+// for the average of an empty slice, which pass.
+func compareTotal(v interface{}, param string, avg bool) (c int, ok bool, err error) {
+	p, err := asBigRat(param)
+	if err != nil {
+		return 0, false, ErrBadParameter
+	}
+	total, n, approx, err := sumOf(v)
+	if total == nil || err != nil || avg && n == 0 {
+		return 0, false, err
+	}
+	if avg {
+		total.Quo(total, new(big.Rat).SetInt64(int64(n)))
+	}
+	return compareSum(total, p, approx), true, nil
+}
+
+// sum tests whether the numbers of a slice add up to the given value,
+// e.g. sum=100 for percentages. Integers are added exactly, floats
+// within a relative tolerance of 1e-9.
+func sum(v interface{}, param string) error {
+	c, ok, err := compareTotal(v, param, false)
+	if !ok {
+		return err
+	}
+	if c != 0 {
+		return ErrSum
+	}
+	return nil
+}
+
+// summin tests whether the numbers of a slice add up to at least the
+// given value.
+func summin(v interface{}, param string) error {
+	c, ok, err := compareTotal(v, param, false)
+	if !ok {
+		return err
+	}
+	if c < 0 {
+		return ErrSumMin
+	}
+	return nil
+}
+
+// summax tests whether the numbers of a slice add up to at most the
+// given value.
+func summax(v interface{}, param string) error {
+	c, ok, err := compareTotal(v, param, false)
+	if !ok {
+		return err
+	}
+	if c > 0 {
+		return ErrSumMax
+	}
+	return nil
+}
+
+// avgmin tests whether the average of the numbers of a non-empty slice
+// is at least the given value, e.g. avgmin=0.5.
+func avgmin(v interface{}, param string) error {
+	c, ok, err := compareTotal(v, param, true)
+	if !ok {
+		return err
+	}
+	if c < 0 {
+		return ErrAvgMin
+	}
+	return nil
+}
+
+// avgmax tests whether the average of the numbers of a non-empty slice
+// is at most the given value.
+func avgmax(v interface{}, param string) error {
+	c, ok, err := compareTotal(v, param, true)
+	if !ok {
+		return err
+	}
+	if c > 0 {
+		return ErrAvgMax
+	}
+	return nil
+}
@@ -0,0 +1,500 @@
+package govalidator
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type Invitation struct {
+	Emails  []string  `valid:"unique"`
+	Members []Invitee `valid:"unique=Email"`
+}
+
+type Series struct {
+	Points []Point `valid:"sorted=At"`
+	Offers []Offer `valid:"sorted=Price,desc"`
+}
+
+type Point struct {
+	At    time.Time
+	Value float64
+}
+
+type Offer struct {
+	Price *big.Rat
+}
+
+type Scores struct {
+	Values []int    `valid:"eachmin=0;eachmax=100"`
+	Codes  []string `valid:"eachlen=2"`
+}
+
+type Palette struct {
+	Colors []string `valid:"eachenum=red,green,blue"`
+}
+
+type Package struct {
+	Metadata map[string]string `valid:"haskeys=name,version;onlykeys=name,version,license"`
+}
+
+type TagSelection struct {
+	Selected    []string `valid:"subsetof=AllowedTags;disjointwith=BlockedTags"`
+	AllowedTags []string
+	BlockedTags []string
+	Numbers     []int `valid:"subsetof=Missing"`
+}
+
+type LabelSet struct {
+	Labels map[string][]string `valid:"max=10;totalmax=5"`
+}
+
+type Split struct {
+	Shares []float64 `valid:"sum=100"`
+	Scores []int     `valid:"avgmin=0.5;summax=10"`
+}
+
+type Batch struct {
+	Items []*Invitee `valid:"min=1;nonilitems"`
+}
+
+type Invitee struct {
+	Email string
+	Name  string
+}
+
+type contact struct {
+	email string
+}
+
+func TestUnique(t *testing.T) {
+	a, b := "a", "b"
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+		dups  []int
+	}{
+		{[]string{"a", "b", "c"}, "", nil, nil},
+		{[]string{"a", "b", "a", "c", "b", "a"}, "", ErrDuplicate, []int{2, 4, 5}},
+		{[]int{1, 2, 3, 2}, "", ErrDuplicate, []int{3}},
+		{[3]float64{1, 1.5, 1}, "", ErrDuplicate, []int{2}},
+		{[]*string{&a, nil, &b, nil}, "", nil, nil},
+		{[]*string{&a, &b, &a}, "", ErrDuplicate, []int{2}},
+		{[]interface{}{1, "1", 1}, "", ErrDuplicate, []int{2}},
+		{&[]string{"x", "x"}, "", ErrDuplicate, []int{1}},
+		{[]string{}, "", nil, nil},
+		{[]string(nil), "", nil, nil},
+		{(*[]string)(nil), "", nil, nil},
+		{[]Invitee{{"a@x.io", "A"}, {"b@x.io", "A"}}, "Email", nil, nil},
+		{[]Invitee{{"a@x.io", "A"}, {"b@x.io", "B"}, {"a@x.io", "C"}}, "Email", ErrDuplicate, []int{2}},
+		{[]*Invitee{{"a@x.io", "A"}, nil, {"a@x.io", "B"}}, "Email", ErrDuplicate, []int{2}},
+		{[]Invitee{{"a@x.io", "A"}}, "Missing", ErrBadParameter, nil},
+		{[]contact{{"a@x.io"}, {"a@x.io"}}, "email", ErrBadParameter, nil},
+		{[]string{"a"}, "Email", ErrUnsupported, nil},
+		{[]string{"a"}, "Email,Name", ErrBadParameter, nil},
+		{[]string{"Go", "go", " GO ", "rust"}, "ci", ErrDuplicate, []int{1, 2}},
+		{[]string{"Go", "go", " go"}, "fold", ErrDuplicate, []int{1}},
+		{[]string{"go", " go", "Go"}, "trim", ErrDuplicate, []int{1}},
+		{[]string{"go", " Go"}, "trim,fold", ErrDuplicate, []int{1}},
+		{[]string{"straße", "STRASSE"}, "ci", nil, nil},
+		{[]string{"ǅ", "ǆ", "Ǆ"}, "ci", ErrDuplicate, []int{1, 2}},
+		{[]string{"K", "\u212a"}, "ci", ErrDuplicate, []int{1}},
+		{[]Invitee{{"A@x.io", "A"}, {"a@X.io", "B"}}, "Email,ci", ErrDuplicate, []int{1}},
+		{[]Invitee{{"A@x.io", "A"}, {"a@X.io", "B"}}, "ci,Email", ErrDuplicate, []int{1}},
+		{[]int{1, 1}, "ci", ErrUnsupported, nil},
+		{[][]string{{"a"}}, "", ErrUnsupported, nil},
+		{"abc", "", ErrUnsupported, nil},
+	}
+	for i, tt := range tests {
+		err := unique(tt.v, tt.param)
+		if !errors.Is(err, tt.err) || err == nil && tt.err != nil {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+			continue
+		}
+		var de *DuplicateError
+		if errors.As(err, &de) != (tt.dups != nil) || de != nil && !reflect.DeepEqual(de.Indexes, tt.dups) {
+			t.Errorf("%d: expected indexes %v, got %v", i, tt.dups, err)
+		}
+	}
+
+	resp, _ := New().Validate(Invitation{
+		Emails:  []string{"a@x.io", "b@x.io", "a@x.io"},
+		Members: []Invitee{{"a@x.io", "A"}, {"a@x.io", "B"}, {"a@x.io", "C"}},
+	})
+	if err := resp["Emails"]; err == nil || err.Error() != "contains duplicates at indexes 2" {
+		t.Fatalf("resp: %v", resp)
+	}
+	if err := resp["Members"]; err == nil || err.Error() != "contains duplicates at indexes 1, 2" {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func TestSorted(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	one := 1
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{[]int{1, 2, 2, 3}, "", nil},
+		{[]int{1, 3, 2}, "", ErrUnsorted},
+		{[]int{3, 2, 2, 1}, "desc", nil},
+		{[]int{3, 1, 2}, "desc", ErrUnsorted},
+		{[]int{1, 2}, "asc", nil},
+		{[]uint8{1, 2, 255}, "", nil},
+		{[2]float64{1.5, -1}, "", ErrUnsorted},
+		{[]string{"a", "b", "ba"}, "", nil},
+		{[]string{"b", "a"}, "", ErrUnsorted},
+		{[]time.Time{t0, t0.Add(time.Second)}, "", nil},
+		{[]time.Time{t0, t0.Add(-time.Second)}, "", ErrUnsorted},
+		{[]*big.Int{big.NewInt(1), big.NewInt(10)}, "", nil},
+		{[]big.Int{*big.NewInt(10), *big.NewInt(1)}, "", ErrUnsorted},
+		{[]*int{nil, &one, nil}, "", nil},
+		{[]Point{{At: t0}, {At: t0.Add(time.Hour)}}, "At", nil},
+		{[]Point{{At: t0, Value: 2}, {At: t0, Value: 1}}, "Value,asc", ErrUnsorted},
+		{[]Point{{Value: 2}, {Value: 1}}, "Value,desc", nil},
+		{[]Point{{Value: 2}, {Value: 1}}, "desc,Value", nil},
+		{[]int{}, "", nil},
+		{[]int(nil), "", nil},
+		{[]int{1}, "up", ErrUnsupported},
+		{[]Point{{}}, "Missing", ErrBadParameter},
+		{[]contact{{"b"}, {"a"}}, "email", ErrBadParameter},
+		{[]int{1}, "asc,desc", ErrBadParameter},
+		{[]Point{{}}, "At,Value", ErrBadParameter},
+		{[]interface{}{1, "a"}, "", ErrUnsupported},
+		{[]Point{{}, {}}, "", ErrUnsupported},
+		{"abc", "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := sorted(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+
+	resp, _ := New().Validate(Series{
+		Points: []Point{{At: t0.Add(time.Minute)}, {At: t0}},
+		Offers: []Offer{{big.NewRat(3, 2)}, {big.NewRat(1, 2)}},
+	})
+	if len(resp) != 1 || resp["Points"] != ErrUnsorted {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func TestEach(t *testing.T) {
+	v := New()
+	one, big := 1, 1000
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+		index int
+	}{
+		{v.eachmin, []int{0, 5, 100}, "0", nil, 0},
+		{v.eachmin, []int{0, -1, -2}, "0", ErrMin, 1},
+		{v.eachmax, []float64{0, 99.5, 100.5}, "100", ErrMax, 2},
+		{v.eachmax, [2]uint{1, 2}, "2", nil, 0},
+		{v.eachmax, []*int{&one, nil, &big}, "10", ErrMax, 2},
+		{v.eachmin, []string{"ab", "a"}, "2", ErrMin, 1},
+		{v.eachmax, []string{"héé"}, "3", nil, 0},
+		{v.eachlen, []string{"de", "fr", "eng"}, "2", ErrLen, 2},
+		{v.eachlen, [][]int{{1}, {1, 2}}, "1", ErrLen, 1},
+		{v.eachmin, []int{}, "x", nil, 0},
+		{v.eachmin, []int(nil), "0", nil, 0},
+		{v.eachmin, &[]int{-1}, "0", ErrMin, 0},
+		{v.eachmin, []int{1}, "x", ErrBadParameter, 0},
+		{v.eachmin, []bool{true}, "0", ErrUnsupported, 0},
+		{v.eachmin, 5, "0", ErrUnsupported, 0},
+	}
+	for i, tt := range tests {
+		err := tt.fn(tt.v, tt.param)
+		if !errors.Is(err, tt.err) || err == nil && tt.err != nil {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+			continue
+		}
+		var ee *ElementError
+		if errors.As(err, &ee) && ee.Index != tt.index {
+			t.Errorf("%d: expected index %d, got %v", i, tt.index, err)
+		}
+	}
+
+	resp, _ := New().Validate(Scores{Values: []int{10, 101, 5}, Codes: []string{"de", "x"}})
+	if err := resp["Values"]; err == nil || err.Error() != "element 1 (101): greater than max" {
+		t.Fatalf("resp: %v", resp)
+	}
+	if err := resp["Codes"]; !errors.Is(err, ErrLen) {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func TestNoNilItems(t *testing.T) {
+	one := 1
+	tests := []struct {
+		v     interface{}
+		err   error
+		index int
+	}{
+		{[]*int{&one, &one}, nil, 0},
+		{[]*int{&one, nil}, ErrNilItem, 1},
+		{[]interface{}{1, "a", nil}, ErrNilItem, 2},
+		{[]interface{}{(*int)(nil)}, ErrNilItem, 0},
+		{[2]*int{&one}, ErrNilItem, 1},
+		{&[]*int{nil}, ErrNilItem, 0},
+		{[]*int{}, nil, 0},
+		{[]*int(nil), nil, 0},
+		{[]int{0}, ErrUnsupported, 0},
+		{"a", ErrUnsupported, 0},
+	}
+	for i, tt := range tests {
+		err := nonilitems(tt.v, "")
+		if !errors.Is(err, tt.err) || err == nil && tt.err != nil {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+			continue
+		}
+		var ee *ElementError
+		if errors.As(err, &ee) && ee.Index != tt.index {
+			t.Errorf("%d: expected index %d, got %v", i, tt.index, err)
+		}
+	}
+
+	resp, _ := New().Validate(Batch{Items: []*Invitee{{Email: "a@x.io"}, nil}})
+	if err := resp["Items"]; err == nil || err.Error() != "element 1: is nil" {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func TestEachEnum(t *testing.T) {
+	red := "red"
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+		index int
+		value interface{}
+	}{
+		{[]string{"red", "blue", "red"}, "red,green,blue", nil, 0, nil},
+		{[]string{"red", "purple"}, "red,green,blue", ErrEnum, 1, "purple"},
+		{[]int{1, 2, 4}, "1,2,3", ErrEnum, 2, 4},
+		{[]*string{&red, nil}, "red", nil, 0, nil},
+		{&[]float64{0.5}, "0.25", ErrEnum, 0, 0.5},
+		{[]string{}, "red", nil, 0, nil},
+		{[]int{1}, "a", ErrBadParameter, 0, nil},
+		{[]bool{true}, "true", ErrUnsupported, 0, nil},
+	}
+	for i, tt := range tests {
+		err := New().eachenum(tt.v, tt.param)
+		if !errors.Is(err, tt.err) || err == nil && tt.err != nil {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+			continue
+		}
+		var ee *ElementError
+		if errors.As(err, &ee) && (ee.Index != tt.index || ee.Value != tt.value) {
+			t.Errorf("%d: expected %d, %v, got %v", i, tt.index, tt.value, err)
+		}
+	}
+
+	resp, _ := New().Validate(Palette{Colors: []string{"red", "teal"}})
+	if err := resp["Colors"]; err == nil || err.Error() != "element 1 (teal): must be one of: red, green, blue" {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	meta := map[string]string{"name": "x", "version": "1", "license": "MIT"}
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+		key   string
+	}{
+		{haskeys, meta, "name,version", nil, ""},
+		{haskeys, meta, "name, author", ErrMissingKey, "author"},
+		{haskeys, map[string]int{}, "name", ErrMissingKey, "name"},
+		{haskeys, map[int]bool{1: true, 2: true}, "1,2", nil, ""},
+		{haskeys, map[uint8]bool{1: true}, "1,3", ErrMissingKey, "3"},
+		{haskeys, &meta, "name", nil, ""},
+		{haskeys, map[string]int(nil), "name", nil, ""},
+		{haskeys, meta, "", ErrBadParameter, ""},
+		{haskeys, map[float64]bool{1: true}, "1", ErrUnsupported, ""},
+		{haskeys, []string{"name"}, "name", ErrUnsupported, ""},
+		{onlykeys, meta, "name,version,license", nil, ""},
+		{onlykeys, meta, "name,version", ErrUnexpectedKey, "license"},
+		{onlykeys, map[string]int{"b": 1, "a": 2, "c": 3}, "c", ErrUnexpectedKey, "a"},
+		{onlykeys, map[string]int{}, "name", nil, ""},
+		{onlykeys, map[int]bool{-1: true}, "1", ErrUnexpectedKey, "-1"},
+		{onlykeys, meta, "", ErrBadParameter, ""},
+	}
+	for i, tt := range tests {
+		err := tt.fn(tt.v, tt.param)
+		if !errors.Is(err, tt.err) || err == nil && tt.err != nil {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+			continue
+		}
+		var ke *KeyError
+		if errors.As(err, &ke) && ke.Key != tt.key {
+			t.Errorf("%d: expected key %q, got %v", i, tt.key, err)
+		}
+	}
+
+	resp, _ := New().Validate(Package{Metadata: map[string]string{"name": "x"}})
+	if err := resp["Metadata"]; err == nil || err.Error() != `missing key "version"` {
+		t.Fatalf("resp: %v", resp)
+	}
+	resp, _ = New().Validate(Package{Metadata: map[string]string{"name": "x", "version": "1", "owner": "y"}})
+	if err := resp["Metadata"]; err == nil || err.Error() != `unexpected key "owner"` {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func TestSubsetDisjoint(t *testing.T) {
+	a := "a"
+	tests := []struct {
+		fn    validateFieldFunc
+		v     interface{}
+		other interface{}
+		err   error
+		index int
+	}{
+		{subsetof, []string{"a", "b"}, []string{"c", "b", "a"}, nil, 0},
+		{subsetof, []string{"a", "x"}, []string{"a", "b"}, ErrNotSubset, 1},
+		{subsetof, []string{"a"}, []string(nil), ErrNotSubset, 0},
+		{subsetof, []string{}, []string(nil), nil, 0},
+		{subsetof, []*string{&a, nil}, [1]string{"a"}, nil, 0},
+		{subsetof, []int{1, 2}, &[]int{2, 1}, nil, 0},
+		{subsetof, []string(nil), []string{"a"}, nil, 0},
+		{disjointwith, []string{"a", "b"}, []string{"c"}, nil, 0},
+		{disjointwith, []string{"a", "b"}, []string{"b"}, ErrNotDisjoint, 1},
+		{disjointwith, []string{"a"}, []string(nil), nil, 0},
+		{subsetof, []string{"a"}, "a", ErrUnsupported, 0},
+		{subsetof, "a", []string{"a"}, ErrUnsupported, 0},
+		{subsetof, [][]int{{1}}, [][]int{{1}}, ErrUnsupported, 0},
+	}
+	for i, tt := range tests {
+		err := tt.fn(tt.v, tt.other)
+		if !errors.Is(err, tt.err) || err == nil && tt.err != nil {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+			continue
+		}
+		var ee *ElementError
+		if errors.As(err, &ee) && ee.Index != tt.index {
+			t.Errorf("%d: expected index %d, got %v", i, tt.index, err)
+		}
+	}
+
+	resp, _ := New().Validate(TagSelection{
+		Selected:    []string{"go", "rust"},
+		AllowedTags: []string{"go", "rust", "zig"},
+		BlockedTags: []string{"rust"},
+	})
+	if err := resp["Selected"]; err == nil || err.Error() != "element 1 (rust): among the excluded values" {
+		t.Fatalf("resp: %v", resp)
+	}
+	resp, _ = New().Validate(TagSelection{Selected: []string{"c"}, AllowedTags: []string{"go"}, Numbers: []int{1}})
+	if !errors.Is(resp["Selected"], ErrNotSubset) || resp["Numbers"] != ErrBadParameter {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	v := New()
+	v.SetIgnoreCase(true)
+	v.SetStrict(true)
+	resp, _ = v.Validate(struct {
+		Tags    []string `valid:"SubsetOf=Allowed"`
+		Allowed []string
+	}{Tags: []string{"x"}})
+	if !errors.Is(resp["Tags"], ErrNotSubset) {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func TestTotalMax(t *testing.T) {
+	one := 1
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{map[string][]string{"a": {"1", "2"}, "b": {"3"}}, "3", nil},
+		{map[string][]string{"a": {"1", "2"}, "b": {"3", "4"}}, "3", ErrTotalMax},
+		{[][]int{{1, 2}, {}, {3}}, "3", nil},
+		{[][]int{{1, 2}, {3, 4}}, "3", ErrTotalMax},
+		{[][][]int{{{1}, {2, 3}}, {{4}}}, "4", nil},
+		{[][][]int{{{1}, {2, 3}}, {{4, 5}}}, "4", ErrTotalMax},
+		{[]map[string]int{{"a": 1, "b": 2}, {"c": 3}}, "2", ErrTotalMax},
+		{[][]byte{[]byte("abc"), []byte("de")}, "2", nil},
+		{map[string][16]byte{"a": {}, "b": {}}, "2", nil},
+		{[][16]byte{{}, {}, {}}, "2", ErrTotalMax},
+		{[]string{"abc", "de"}, "1", ErrTotalMax},
+		{[]*int{&one, nil}, "1", nil},
+		{[][2]int{{1, 2}}, "2", nil},
+		{map[string][]string{}, "0", nil},
+		{[][]int(nil), "0", nil},
+		{[][]int{{1}}, "-1", ErrBadParameter},
+		{[][]int{{1}}, "x", ErrBadParameter},
+		{"abc", "3", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := totalmax(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+
+	resp, _ := New().Validate(LabelSet{Labels: map[string][]string{"env": {"prod", "dev"}, "team": {"a", "b", "c", "d"}}})
+	if len(resp) != 1 || resp["Labels"] != ErrTotalMax {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func TestSum(t *testing.T) {
+	one := 1
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{sum, []int{50, 30, 20}, "100", nil},
+		{sum, []int{50, 30, 21}, "100", ErrSum},
+		{sum, []float64{33.3, 33.3, 33.4}, "100", nil},
+		{sum, []float64{0.1, 0.2}, "0.3", nil},
+		{sum, []float64{33.3, 33.3, 33.3}, "100", ErrSum},
+		{sum, []float32{0.25, 0.75}, "1", nil},
+		{sum, []int64{math.MaxInt64, math.MaxInt64}, "18446744073709551614", nil},
+		{sum, []uint8{200, 100}, "300", nil},
+		{sum, []*int{&one, nil, &one}, "2", nil},
+		{sum, []interface{}{1, 2.5}, "3.5", nil},
+		{sum, []int{}, "0", nil},
+		{sum, (*[]int)(nil), "1", nil},
+		{summin, []int{1, 2}, "3", nil},
+		{summin, []int{1, 1}, "3", ErrSumMin},
+		{summin, []int{}, "1", ErrSumMin},
+		{summax, []int{4, 6}, "10", nil},
+		{summax, []int{5, 6}, "10", ErrSumMax},
+		{summax, []float64{-1.5, 1}, "-1/2", nil},
+		{avgmin, []float64{0.4, 0.6}, "0.5", nil},
+		{avgmin, []float64{0.4, 0.5}, "0.5", ErrAvgMin},
+		{avgmin, []int{1, 0}, "0.5", nil},
+		{avgmin, []int{}, "0.5", nil},
+		{avgmax, []int{1, 2, 4}, "2", ErrAvgMax},
+		{avgmax, []int{1, 2, 3}, "2", nil},
+		{sum, []int{1}, "x", ErrBadParameter},
+		{sum, []string{"1"}, "1", ErrUnsupported},
+		{sum, []float64{math.NaN()}, "1", ErrUnsupported},
+		{sum, 5, "5", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+
+	resp, _ := New().Validate(Split{Shares: []float64{60, 30}, Scores: []int{0, 0, 1}})
+	if len(resp) != 2 || resp["Shares"] != ErrSum || resp["Scores"] != ErrAvgMin {
+		t.Fatalf("resp: %v", resp)
+	}
+}
@@ -0,0 +1,55 @@
+package govalidator
+
+import "testing"
+
+func TestCountry(t *testing.T) {
+	v := New()
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"DE", "", nil},
+		{"DE", "alpha2", nil},
+		{"DEU", "alpha3", nil},
+		{"276", "numeric", nil},
+		{276, "numeric", nil},
+		{8, "numeric", nil},
+		{"", "", nil},
+		{"de", "", ErrCountry},
+		{"DEU", "", ErrCountry},
+		{"DE", "alpha3", ErrCountry},
+		{"XK", "", ErrCountry},
+		{"999", "numeric", ErrCountry},
+		{"DE", "DE,FR,AT", nil},
+		{"US", "DE,FR,AT", ErrCountryNotAllowed},
+		{"FRA", "alpha3,DE,FR,AT", nil},
+		{"250", "numeric,DEU,FRA", nil},
+		{"USA", "alpha3,DEU,FRA", ErrCountryNotAllowed},
+		{"DE", "DE,XX", ErrBadParameter},
+		{1.5, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := v.country(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+type Market struct {
+	Country string `valid:"country"`
+}
+
+func TestSetCountries(t *testing.T) {
+	v := New()
+	if resp, _ := v.Validate(Market{Country: "XK"}); resp["Country"] != ErrCountry {
+		t.Fatalf("resp: %v", resp)
+	}
+	v.SetCountries(append(isoCountries, Country{Alpha2: "XK", Alpha3: "XKX", Numeric: "983"}))
+	if resp, _ := v.Validate(Market{Country: "XK"}); len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+	if len(isoCountries) != 249 {
+		t.Fatalf("built-in table modified: %d", len(isoCountries))
+	}
+}
@@ -0,0 +1,280 @@
+package govalidator
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"strings"
+)
+
+var (
+	ErrBTCAddress = errors.New("invalid bitcoin address")
+	ErrETHAddress = errors.New("invalid ethereum address")
+)
+
+// btcNetwork are the address prefixes of a bitcoin network: the version
+// bytes of base58check P2PKH and P2SH addresses and the bech32 human
+// readable part of segwit addresses.
+type btcNetwork struct {
+	p2pkh, p2sh byte
+	hrp         string
+}
+
+var btcNetworks = map[string]btcNetwork{
+	"mainnet": {p2pkh: 0x00, p2sh: 0x05, hrp: "bc"},
+	"testnet": {p2pkh: 0x6f, p2sh: 0xc4, hrp: "tb"},
+}
+
+// btcAddr tests whether a string is a bitcoin address, legacy base58check
+// (P2PKH, P2SH) or segwit bech32/bech32m (BIP 173, BIP 350), verifying
+// its checksum. Addresses are of mainnet unless the parameter is testnet.
+func btcAddr(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if param == "" {
+		param = "mainnet"
+	}
+	network, ok := btcNetworks[param]
+	if !ok {
+		return ErrBadParameter
+	}
+	if strings.HasPrefix(strings.ToLower(s), network.hrp+"1") {
+		if !isSegwitAddress(s, network.hrp) {
+			return ErrBTCAddress
+		}
+		return nil
+	}
+	payload, ok := base58Check(s)
+	if !ok || len(payload) != 21 || payload[0] != network.p2pkh && payload[0] != network.p2sh {
+		return ErrBTCAddress
+	}
+	return nil
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Check decodes a base58check string and verifies its 4 byte
+// double SHA-256 checksum, returning the payload without it.
+func base58Check(s string) ([]byte, bool) {
+	if len(s) == 0 || len(s) > 128 {
+		return nil, false
+	}
+	var out []byte // big endian
+	for i := 0; i < len(s); i++ {
+		carry := strings.IndexByte(base58Alphabet, s[i])
+		if carry < 0 {
+			return nil, false
+		}
+		for j := len(out) - 1; j >= 0; j-- {
+			carry += int(out[j]) * 58
+			out[j] = byte(carry)
+			carry >>= 8
+		}
+		for ; carry > 0; carry >>= 8 {
+			out = append([]byte{byte(carry)}, out...)
+		}
+	}
+	for i := 0; i < len(s) && s[i] == '1'; i++ {
+		out = append([]byte{0}, out...)
+	}
+	if len(out) < 5 {
+		return nil, false
+	}
+	payload, sum := out[:len(out)-4], out[len(out)-4:]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	if string(second[:4]) != string(sum) {
+		return nil, false
+	}
+	return payload, true
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// The checksum constants of bech32 and bech32m.
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+// isSegwitAddress reports whether s is a segwit address for hrp: version
+// 0 programs of 20 or 32 bytes with a bech32 checksum, or version 1 to 16
+// programs of 2 to 40 bytes with a bech32m checksum.
+func isSegwitAddress(s, hrp string) bool {
+	if len(s) > 90 || strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return false
+	}
+	s = strings.ToLower(s)
+	data := make([]int, 0, len(s)-len(hrp)-1)
+	for i := len(hrp) + 1; i < len(s); i++ {
+		d := strings.IndexByte(bech32Charset, s[i])
+		if d < 0 {
+			return false
+		}
+		data = append(data, d)
+	}
+	if len(data) < 7 {
+		return false
+	}
+	values := make([]int, 0, 2*len(hrp)+1+len(data))
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, int(hrp[i]>>5))
+	}
+	values = append(values, 0)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, int(hrp[i]&31))
+	}
+	checksum := bech32Polymod(append(values, data...))
+
+	version, program := data[0], data[1:len(data)-6]
+	// the 5 bit groups of the program make whole bytes, up to 4 zero
+	// bits of padding
+	n, pad := len(program)*5/8, len(program)*5%8
+	if pad >= 5 || pad > 0 && program[len(program)-1]&(1<<uint(pad)-1) != 0 {
+		return false
+	}
+	switch {
+	case version == 0:
+		return checksum == bech32Const && (n == 20 || n == 32)
+	case version <= 16:
+		return checksum == bech32mConst && n >= 2 && n <= 40
+	}
+	return false
+}
+
+func bech32Polymod(values []int) int {
+	gen := [5]int{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := 1
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ v
+		for i := 0; i < 5; i++ {
+			if (b>>uint(i))&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+// ethAddr tests whether a string is an ethereum address, "0x" and 40 hex
+// digits. Mixed case addresses must match their EIP-55 checksum, all
+// lower or upper case ones carry none and are accepted unless the
+// parameter is checksum.
+func ethAddr(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	requireChecksum := false
+	switch param {
+	case "":
+	case "checksum":
+		requireChecksum = true
+	default:
+		return ErrBadParameter
+	}
+	if len(s) != 42 || s[0] != '0' || s[1] != 'x' && s[1] != 'X' {
+		return ErrETHAddress
+	}
+	hexAddr := s[2:]
+	for i := 0; i < len(hexAddr); i++ {
+		if !isHexDigit(hexAddr[i]) {
+			return ErrETHAddress
+		}
+	}
+	lower := strings.ToLower(hexAddr)
+	if !requireChecksum && (hexAddr == lower || hexAddr == strings.ToUpper(hexAddr)) {
+		return nil
+	}
+	hash := keccak256([]byte(lower))
+	for i := 0; i < len(hexAddr); i++ {
+		c := hexAddr[i]
+		if c <= '9' {
+			continue
+		}
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if (nibble >= 8) != (c <= 'F') {
+			return ErrETHAddress
+		}
+	}
+	return nil
+}
+
+var keccakRoundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+	0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+	0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+var (
+	keccakRotations = [24]int{1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14, 27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44}
+	keccakLanes     = [24]int{10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4, 15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1}
+)
+
+func keccakF1600(a *[25]uint64) {
+	var c [5]uint64
+	for round := 0; round < 24; round++ {
+		for i := 0; i < 5; i++ {
+			c[i] = a[i] ^ a[i+5] ^ a[i+10] ^ a[i+15] ^ a[i+20]
+		}
+		for i := 0; i < 5; i++ {
+			t := c[(i+4)%5] ^ bits.RotateLeft64(c[(i+1)%5], 1)
+			for j := 0; j < 25; j += 5 {
+				a[j+i] ^= t
+			}
+		}
+		t := a[1]
+		for i := 0; i < 24; i++ {
+			j := keccakLanes[i]
+			t, a[j] = a[j], bits.RotateLeft64(t, keccakRotations[i])
+		}
+		for j := 0; j < 25; j += 5 {
+			copy(c[:], a[j:j+5])
+			for i := 0; i < 5; i++ {
+				a[j+i] ^= ^c[(i+1)%5] & c[(i+2)%5]
+			}
+		}
+		a[0] ^= keccakRoundConstants[round]
+	}
+}
+
+// keccak256 is the original Keccak-256 used by ethereum, which differs
+// from the standardized SHA3-256 in its padding.
+func keccak256(data []byte) [32]byte {
+	const rate = 136
+	var a [25]uint64
+	block := make([]byte, rate)
+	for {
+		n := copy(block, data)
+		data = data[n:]
+		if n < rate {
+			for i := n; i < rate; i++ {
+				block[i] = 0
+			}
+			block[n] ^= 0x01
+			block[rate-1] ^= 0x80
+		}
+		for i := 0; i < rate/8; i++ {
+			a[i] ^= binary.LittleEndian.Uint64(block[i*8:])
+		}
+		keccakF1600(&a)
+		if n < rate {
+			break
+		}
+	}
+	var out [32]byte
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(out[i*8:], a[i])
+	}
+	return out
+}
@@ -0,0 +1,77 @@
+package govalidator
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestKeccak256(t *testing.T) {
+	tests := map[string]string{
+		"":    "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		"abc": "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45",
+	}
+	for in, want := range tests {
+		if sum := keccak256([]byte(in)); hex.EncodeToString(sum[:]) != want {
+			t.Errorf("%q: expected %s, got %x", in, want, sum)
+		}
+	}
+}
+
+func TestBTCAddr(t *testing.T) {
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "", nil},
+		{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", "", nil},
+		{"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", "", nil},
+		{"BC1QAR0SRRR7XFKVY5L643LYDNW9RE59GTZZWF5MDQ", "", nil},
+		{"bc1p5d7rjq7g6rdk2yhzks9smlaqtedr4dekq08ge8ztwac72sfr9rusxg3297", "", nil},
+		{"mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn", "testnet", nil},
+		{"tb1qar0srrr7xfkvy5l643lydnw9re59gtzzy00gkn", "testnet", nil},
+		{"", "", nil},
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3", "", ErrBTCAddress},
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN0", "", ErrBTCAddress},
+		{"mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn", "", ErrBTCAddress},
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "testnet", ErrBTCAddress},
+		{"tb1qar0srrr7xfkvy5l643lydnw9re59gtzzy00gkn", "", ErrBTCAddress},
+		{"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdx", "", ErrBTCAddress},
+		{"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzm4yhgz", "", ErrBTCAddress},
+		{"bc1p5d7rjq7g6rdk2yhzks9smlaqtedr4dekq08ge8ztwac72sfr9rusn5pxqu", "", ErrBTCAddress},
+		{"bc1qAR0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", "", ErrBTCAddress},
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "regtest", ErrBadParameter},
+	}
+	for i, tt := range tests {
+		if err := btcAddr(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+func TestETHAddr(t *testing.T) {
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "", nil},
+		{"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", "", nil},
+		{"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB", "", nil},
+		{"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb", "checksum", nil},
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "", nil},
+		{"0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED", "", nil},
+		{"", "", nil},
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "checksum", ErrETHAddress},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", "", ErrETHAddress},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAe", "", ErrETHAddress},
+		{"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed00", "", ErrETHAddress},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeg", "", ErrETHAddress},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "strict", ErrBadParameter},
+	}
+	for i, tt := range tests {
+		if err := ethAddr(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package govalidator
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+var (
+	ErrCSV        = errors.New("invalid csv")
+	ErrCSVColumns = errors.New("wrong number of csv columns")
+)
+
+// CSVError reports the line of the first offending record of a csv
+// field. It unwraps to ErrCSV or ErrCSVColumns.
+type CSVError struct {
+	Line int
+	Err  error
+}
+
+func (e *CSVError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *CSVError) Unwrap() error {
+	return e.Err
+}
+
+// csvDelimiters are the delimiters csv accepts by name.
+var csvDelimiters = map[string]rune{
+	"comma":     ',',
+	"semicolon": ';',
+	"tab":       '\t',
+	"pipe":      '|',
+}
+
+// isCSV tests whether a string or []byte parses as RFC 4180 CSV with the
+// number of columns given as first parameter in every row, or the same
+// number as the first row if omitted. The optional second parameter is the
+// delimiter, a character or one of comma, semicolon, tab and pipe, e.g.
+// csv=3,tab. Failures are a *CSVError giving the line of the first
+// offending row.
+func isCSV(v interface{}, param string) error {
+	b, err := jsonBytes(v)
+	if err != nil || len(b) == 0 {
+		return err
+	}
+	params := SplitParams(param)
+	if len(params) > 2 {
+		return ErrBadParameter
+	}
+	columns := 0
+	if len(params) > 0 && params[0] != "" {
+		if columns, err = strconv.Atoi(params[0]); err != nil || columns <= 0 {
+			return ErrBadParameter
+		}
+	}
+	delim := ','
+	if len(params) == 2 {
+		d, ok := csvDelimiters[params[1]]
+		if !ok {
+			if utf8.RuneCountInString(params[1]) != 1 {
+				return ErrBadParameter
+			}
+			d, _ = utf8.DecodeRuneInString(params[1])
+		}
+		delim = d
+	}
+	if !validCSVDelim(delim) {
+		return ErrBadParameter
+	}
+
+	r := csv.NewReader(bytes.NewReader(b))
+	r.Comma = delim
+	r.FieldsPerRecord = columns
+	r.ReuseRecord = true
+	for {
+		_, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			var pe *csv.ParseError
+			if !errors.As(err, &pe) {
+				return ErrCSV
+			}
+			if errors.Is(pe.Err, csv.ErrFieldCount) {
+				return &CSVError{Line: pe.StartLine, Err: ErrCSVColumns}
+			}
+			return &CSVError{Line: pe.Line, Err: ErrCSV}
+		}
+	}
+}
+
+// validCSVDelim mirrors the delimiters encoding/csv accepts.
+func validCSVDelim(r rune) bool {
+	return r != 0 && r != '"' && r != '\r' && r != '\n' && utf8.ValidRune(r) && r != utf8.RuneError
+}
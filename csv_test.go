@@ -0,0 +1,57 @@
+package govalidator
+
+import (
+	"errors"
+	"testing"
+)
+
+type Import struct {
+	Rows string `valid:"csv=3"`
+}
+
+func TestCSV(t *testing.T) {
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+		line  int
+	}{
+		{"a,b,c\n1,2,3\n", "3", nil, 0},
+		{"a,b,c\r\n1,2,3", "3", nil, 0},
+		{`a,"b,with comma",c`, "3", nil, 0},
+		{"a,\"multi\nline\",c\n1,2,3", "3", nil, 0},
+		{"a,b,c\n1,2\n", "3", ErrCSVColumns, 2},
+		{"a,b\n1,2\n", "3", ErrCSVColumns, 1},
+		{"a,b,c\n1,2,3\n4,5,6,7", "", ErrCSVColumns, 3},
+		{"a,b\n1,2", "", nil, 0},
+		{"a,b,c\n1,x\"y,3", "3", ErrCSV, 2},
+		{"a,b,c\n\"open,2,3", "3", ErrCSV, 2},
+		{"a\tb\tc\n1\t2\t3", "3,tab", nil, 0},
+		{"a;b;c", "3,semicolon", nil, 0},
+		{"a|b|c", "3,|", nil, 0},
+		{"a;b;c", "3", ErrCSVColumns, 1},
+		{[]byte("a,b,c"), "3", nil, 0},
+		{"", "3", nil, 0},
+		{"a", "0", ErrBadParameter, 0},
+		{"a", "3,\"", ErrBadParameter, 0},
+		{"a", "3,ab", ErrBadParameter, 0},
+		{"a", "3,tab,x", ErrBadParameter, 0},
+		{3, "3", ErrUnsupported, 0},
+	}
+	for i, tt := range tests {
+		err := isCSV(tt.v, tt.param)
+		if !errors.Is(err, tt.err) || err == nil && tt.err != nil {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+			continue
+		}
+		var ce *CSVError
+		if errors.As(err, &ce) != (tt.line > 0) || ce != nil && ce.Line != tt.line {
+			t.Errorf("%d: expected line %d, got %v", i, tt.line, err)
+		}
+	}
+
+	resp, _ := New().Validate(Import{Rows: "name,email,role\nann,ann@example.com\n"})
+	if err := resp["Rows"]; !errors.Is(err, ErrCSVColumns) || err.Error() != "line 2: wrong number of csv columns" {
+		t.Fatalf("resp: %v", resp)
+	}
+}
@@ -0,0 +1,84 @@
+package govalidator
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var ErrDenyPattern = errors.New("matches a denied pattern")
+
+// builtinDenyPatterns are the pattern sets known out of the box:
+//
+//	traversal  path traversal sequences, plain or URL encoded, and NUL
+//	template   template and expression language markers, "{{", "${", "<%"
+//	shell      shell metacharacters and command substitution
+func builtinDenyPatterns() map[string][]*regexp.Regexp {
+	return map[string][]*regexp.Regexp{
+		"traversal": {
+			regexp.MustCompile(`(^|[/\\])\.\.([/\\]|$)`),
+			regexp.MustCompile(`(?i)(%2e|\.)(%2e|\.)(%2f|%5c|[/\\])`),
+			regexp.MustCompile(`(?i)%25(2e|2f|5c)`),
+			regexp.MustCompile(`\x00|%00`),
+		},
+		"template": {
+			regexp.MustCompile(`\{\{|\}\}`),
+			regexp.MustCompile(`[$#]\{`),
+			regexp.MustCompile(`<%|%>`),
+			regexp.MustCompile(`\{%|%\}`),
+		},
+		"shell": {
+			regexp.MustCompile("[;&|`<>\\n\\r]"),
+			regexp.MustCompile(`\$[({\w]`),
+		},
+	}
+}
+
+func SetDenyPatterns(name string, patterns []*regexp.Regexp) {
+	defaultValidator.SetDenyPatterns(name, patterns)
+}
+
+// SetDenyPatterns registers a set of patterns that tags can reference as
+// denypattern=@name, so that blocklists are maintained in one place. It
+// replaces the built-in sets of the same name, empty patterns remove the
+// set.
+func (d *Validator) SetDenyPatterns(name string, patterns []*regexp.Regexp) {
+	if name == "" {
+		return
+	}
+	if len(patterns) == 0 {
+		delete(d.denyPatterns, name)
+		return
+	}
+	d.denyPatterns[name] = append([]*regexp.Regexp(nil), patterns...)
+}
+
+// denypattern tests whether a string matches none of the patterns of the
+// sets given as parameters, e.g. denypattern=@traversal,@shell. See
+// SetDenyPatterns.
+func (d *Validator) denypattern(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	names := SplitParams(param)
+	if len(names) == 0 {
+		return ErrBadParameter
+	}
+	var sets [][]*regexp.Regexp
+	for _, name := range names {
+		set, ok := d.denyPatterns[strings.TrimPrefix(name, "@")]
+		if !ok || !strings.HasPrefix(name, "@") {
+			return ErrBadParameter
+		}
+		sets = append(sets, set)
+	}
+	for _, set := range sets {
+		for _, re := range set {
+			if re.MatchString(s) {
+				return ErrDenyPattern
+			}
+		}
+	}
+	return nil
+}
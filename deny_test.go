@@ -0,0 +1,62 @@
+package govalidator
+
+import (
+	"regexp"
+	"testing"
+)
+
+type SubmittedFile struct {
+	Name string `valid:"denypattern=@traversal,@shell"`
+	Note string `valid:"denypattern=@profanity"`
+}
+
+func TestDenyPattern(t *testing.T) {
+	d := New()
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"reports/2024/q1.pdf", "@traversal", nil},
+		{"file..name.txt", "@traversal", nil},
+		{"../etc/passwd", "@traversal", ErrDenyPattern},
+		{`a\..\b`, "@traversal", ErrDenyPattern},
+		{"a/..", "@traversal", ErrDenyPattern},
+		{"%2e%2e%2fetc", "@traversal", ErrDenyPattern},
+		{"..%2Fetc", "@traversal", ErrDenyPattern},
+		{"%252e%252e", "@traversal", ErrDenyPattern},
+		{"a\x00b", "@traversal", ErrDenyPattern},
+		{"Hello {name}", "@template", nil},
+		{"Hello {{.Name}}", "@template", ErrDenyPattern},
+		{"${jndi:ldap://x}", "@template", ErrDenyPattern},
+		{"<%= 7*7 %>", "@template", ErrDenyPattern},
+		{"#{7*7}", "@template", ErrDenyPattern},
+		{"report-final_v2.txt", "@shell", nil},
+		{"50% off (today)", "@shell", nil},
+		{"a; rm -rf /", "@shell", ErrDenyPattern},
+		{"$(whoami)", "@shell", ErrDenyPattern},
+		{"$HOME", "@shell", ErrDenyPattern},
+		{"`id`", "@shell", ErrDenyPattern},
+		{"x | nc", "@template,@shell", ErrDenyPattern},
+		{"", "@shell", nil},
+		{"x", "", ErrBadParameter},
+		{"x", "shell", ErrBadParameter},
+		{"x", "@missing", ErrBadParameter},
+		{1, "@shell", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := d.denypattern(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %q: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+
+	d.SetDenyPatterns("profanity", []*regexp.Regexp{regexp.MustCompile(`(?i)\bdarn\b`)})
+	resp, _ := d.Validate(SubmittedFile{Name: "../x", Note: "Darn it"})
+	if resp["Name"] != ErrDenyPattern || resp["Note"] != ErrDenyPattern {
+		t.Fatalf("resp: %v", resp)
+	}
+	d.SetDenyPatterns("profanity", nil)
+	if err := d.denypattern("darn", "@profanity"); err != ErrBadParameter {
+		t.Fatalf("removed set: %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package govalidator
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrDisposableEmail = errors.New("disposable email domain not allowed")
+
+// DomainList tells whether a domain belongs to a list, such as the
+// providers of disposable mailboxes. Domains are given in lower case
+// without a trailing dot.
+type DomainList interface {
+	Contains(domain string) bool
+}
+
+// DomainSet is a fixed DomainList. A domain is in the set if it, or one
+// of its parent domains, was added.
+type DomainSet map[string]struct{}
+
+func NewDomainSet(domains ...string) DomainSet {
+	s := make(DomainSet, len(domains))
+	for _, domain := range domains {
+		s[strings.ToLower(strings.TrimSuffix(domain, "."))] = struct{}{}
+	}
+	return s
+}
+
+func (s DomainSet) Contains(domain string) bool {
+	for {
+		if _, ok := s[domain]; ok {
+			return true
+		}
+		dot := strings.IndexByte(domain, '.')
+		if dot < 0 {
+			return false
+		}
+		domain = domain[dot+1:]
+	}
+}
+
+// disposableDomains is the bundled list of disposable email providers,
+// the most common ones seen in signups. Use SetDisposableDomains for a
+// complete or up to date list.
+var disposableDomains = NewDomainSet(
+	"10minutemail.com",
+	"10minutemail.net",
+	"20minutemail.com",
+	"33mail.com",
+	"anonbox.net",
+	"burnermail.io",
+	"discard.email",
+	"dispostable.com",
+	"dropmail.me",
+	"emailondeck.com",
+	"fakeinbox.com",
+	"getairmail.com",
+	"getnada.com",
+	"guerrillamail.biz",
+	"guerrillamail.com",
+	"guerrillamail.de",
+	"guerrillamail.info",
+	"guerrillamail.net",
+	"guerrillamail.org",
+	"guerrillamailblock.com",
+	"harakirimail.com",
+	"inboxbear.com",
+	"incognitomail.org",
+	"mail-temp.com",
+	"mailcatch.com",
+	"maildrop.cc",
+	"mailinator.com",
+	"mailinator.net",
+	"mailnesia.com",
+	"mailpoof.com",
+	"mintemail.com",
+	"moakt.com",
+	"mohmal.com",
+	"mytemp.email",
+	"mytrashmail.com",
+	"nada.email",
+	"sharklasers.com",
+	"spam4.me",
+	"spambox.us",
+	"spamgourmet.com",
+	"temp-mail.io",
+	"temp-mail.org",
+	"tempail.com",
+	"tempinbox.com",
+	"tempmail.com",
+	"tempmail.net",
+	"tempmailo.com",
+	"tempr.email",
+	"throwawaymail.com",
+	"trashmail.com",
+	"trashmail.de",
+	"trashmail.net",
+	"yopmail.com",
+	"yopmail.fr",
+	"yopmail.net",
+)
+
+func SetDisposableDomains(list DomainList) {
+	defaultValidator.SetDisposableDomains(list)
+}
+
+// SetDisposableDomains replaces the list of disposable email domains
+// rejected by email_nodispose, a bundled list by default.
+func (d *Validator) SetDisposableDomains(list DomainList) {
+	if list == nil {
+		return
+	}
+	d.disposable = list
+}
+
+// emailNoDispose checks that a string is an email address, as email
+// does, whose domain isn't a disposable email provider.
+func (d *Validator) emailNoDispose(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	domain, ok := splitEmail(s)
+	if !ok {
+		return ErrEmail
+	}
+	if strings.HasPrefix(domain, "[") {
+		return nil
+	}
+	if d.disposable.Contains(strings.ToLower(strings.TrimSuffix(domain, "."))) {
+		return ErrDisposableEmail
+	}
+	return nil
+}
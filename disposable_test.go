@@ -0,0 +1,54 @@
+package govalidator
+
+import "testing"
+
+func TestDomainSet(t *testing.T) {
+	s := NewDomainSet("Example.com.", "mail.example.org")
+	tests := map[string]bool{
+		"example.com":       true,
+		"inbox.example.com": true,
+		"example.org":       false,
+		"mail.example.org":  true,
+		"notexample.com":    false,
+		"com":               false,
+	}
+	for domain, want := range tests {
+		if got := s.Contains(domain); got != want {
+			t.Errorf("%s: expected %v, got %v", domain, want, got)
+		}
+	}
+}
+
+type Registrant struct {
+	Email string `valid:"email_nodispose"`
+}
+
+func TestEmailNoDispose(t *testing.T) {
+	v := New()
+	tests := []struct {
+		v   string
+		err error
+	}{
+		{"user@example.com", nil},
+		{"user@mailinator.com", ErrDisposableEmail},
+		{"user@YOPMAIL.com.", ErrDisposableEmail},
+		{"user@eu.guerrillamail.com", ErrDisposableEmail},
+		{"user@[192.168.0.1]", nil},
+		{"user", ErrEmail},
+		{"", nil},
+	}
+	for i, tt := range tests {
+		resp, _ := v.Validate(Registrant{Email: tt.v})
+		if resp["Email"] != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, resp["Email"])
+		}
+	}
+
+	v.SetDisposableDomains(NewDomainSet("example.com"))
+	if resp, _ := v.Validate(Registrant{Email: "user@example.com"}); resp["Email"] != ErrDisposableEmail {
+		t.Fatalf("resp: %v", resp)
+	}
+	if resp, _ := v.Validate(Registrant{Email: "user@mailinator.com"}); len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+}
@@ -0,0 +1,310 @@
+package govalidator
+
+import (
+	"encoding/base64"
+	"errors"
+	"mime"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrBase64      = errors.New("invalid base64")
+	ErrHex         = errors.New("invalid hex")
+	ErrDecodedSize = errors.New("decoded size too large")
+	ErrDigest      = errors.New("invalid hex digest")
+
+	ErrDataURI     = errors.New("invalid data uri")
+	ErrDataURIType = errors.New("data uri type not allowed")
+)
+
+// byteUnits are the size suffixes asByteSize understands, longest first.
+var byteUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30},
+	{"KB", 1 << 10}, {"MB", 1 << 20}, {"GB", 1 << 30},
+	{"K", 1 << 10}, {"M", 1 << 20}, {"G", 1 << 30},
+	{"B", 1},
+}
+
+// asByteSize returns the parameter as a number of bytes. It accepts
+// plain numbers and sizes like "512KB" or "1MB", with 1KB = 1024 bytes.
+func asByteSize(param string) (int64, error) {
+	param = strings.TrimSpace(param)
+	mult := int64(1)
+	for _, u := range byteUnits {
+		if strings.HasSuffix(param, u.suffix) {
+			param, mult = strings.TrimSpace(strings.TrimSuffix(param, u.suffix)), u.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(param, 10, 64)
+	if err != nil || n < 0 || n > (1<<62)/mult {
+		return 0, ErrBadParameter
+	}
+	return n * mult, nil
+}
+
+// base64Std tests whether a string is padded standard base64. Use
+// maxdecoded to bound the decoded size.
+func base64Std(v interface{}, param string) error {
+	return checkBase64(v, base64.StdEncoding)
+}
+
+// base64URL tests whether a string is URL-safe base64, with or without
+// padding.
+func base64URL(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	enc := base64.RawURLEncoding
+	if strings.HasSuffix(s, "=") {
+		enc = base64.URLEncoding
+	}
+	return checkBase64(s, enc)
+}
+
+// base64Raw tests whether a string is standard base64 without padding.
+func base64Raw(v interface{}, param string) error {
+	return checkBase64(v, base64.RawStdEncoding)
+}
+
+func checkBase64(v interface{}, enc *base64.Encoding) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if _, err := enc.DecodeString(s); err != nil {
+		return ErrBase64
+	}
+	return nil
+}
+
+// base64DecodedLen returns the number of bytes s decodes to, padded or
+// not, without decoding it.
+func base64DecodedLen(s string) int64 {
+	s = strings.TrimRight(s, "=")
+	return int64(len(s)) * 6 / 8
+}
+
+// hexString tests whether a string is an even number of hex digits, in
+// either case.
+func hexString(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if len(s)%2 != 0 {
+		return ErrHex
+	}
+	for i := 0; i < len(s); i++ {
+		if !isHexDigit(s[i]) {
+			return ErrHex
+		}
+	}
+	return nil
+}
+
+// checkDigest tests whether a string is the hex digest, all in lower or
+// all in upper case, of a hash function whose sums are size bytes.
+func checkDigest(v interface{}, size int) error {
+	return checkString(v, func(s string) bool {
+		// a mix of cases is more likely a typo than a digest
+		if len(s) != 2*size || s != strings.ToLower(s) && s != strings.ToUpper(s) {
+			return false
+		}
+		for i := 0; i < len(s); i++ {
+			if !isHexDigit(s[i]) {
+				return false
+			}
+		}
+		return true
+	}, ErrDigest)
+}
+
+// md5Digest tests whether a string is an MD5 digest, 32 hex digits.
+func md5Digest(v interface{}, param string) error {
+	return checkDigest(v, 16)
+}
+
+// sha1Digest tests whether a string is a SHA-1 digest, 40 hex digits.
+func sha1Digest(v interface{}, param string) error {
+	return checkDigest(v, 20)
+}
+
+// sha256Digest tests whether a string is a SHA-256 digest, 64 hex digits.
+func sha256Digest(v interface{}, param string) error {
+	return checkDigest(v, 32)
+}
+
+// sha384Digest tests whether a string is a SHA-384 digest, 96 hex digits.
+func sha384Digest(v interface{}, param string) error {
+	return checkDigest(v, 48)
+}
+
+// sha512Digest tests whether a string is a SHA-512 digest, 128 hex
+// digits.
+func sha512Digest(v interface{}, param string) error {
+	return checkDigest(v, 64)
+}
+
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+// dataURI tests whether a string is an RFC 2397 data URI such as
+// "data:image/png;base64,iVBORw0K...". The parameters are the allowed
+// media types, "image/*" matching any image, and max:SIZE bounding the
+// decoded payload, e.g. datauri=image/png,image/jpeg,max:1MB.
+func dataURI(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	var types []string
+	max := int64(-1)
+	for _, p := range SplitParams(param) {
+		switch {
+		case strings.HasPrefix(p, "max:"):
+			if max, err = asByteSize(p[len("max:"):]); err != nil {
+				return err
+			}
+		case strings.Contains(p, "/"):
+			types = append(types, strings.ToLower(p))
+		default:
+			return ErrBadParameter
+		}
+	}
+
+	if len(s) < len("data:") || !strings.EqualFold(s[:len("data:")], "data:") {
+		return ErrDataURI
+	}
+	comma := strings.IndexByte(s, ',')
+	if comma < 0 {
+		return ErrDataURI
+	}
+	meta, payload := s[len("data:"):comma], s[comma+1:]
+	isBase64 := false
+	if i := len(meta) - len(";base64"); i >= 0 && strings.EqualFold(meta[i:], ";base64") {
+		meta, isBase64 = meta[:i], true
+	}
+	mediaType := "text/plain"
+	if meta != "" {
+		if strings.HasPrefix(meta, ";") {
+			meta = mediaType + meta
+		}
+		mediaType, _, err = mime.ParseMediaType(meta)
+		if err != nil || !strings.Contains(mediaType, "/") {
+			return ErrDataURI
+		}
+	}
+	if len(types) > 0 && !matchMediaType(mediaType, types) {
+		return ErrDataURIType
+	}
+
+	var size int64
+	if isBase64 {
+		size = base64DecodedLen(payload)
+		if max >= 0 && size > max {
+			return ErrDecodedSize
+		}
+		enc := base64.RawStdEncoding
+		if strings.HasSuffix(payload, "=") {
+			enc = base64.StdEncoding
+		}
+		if _, err := enc.DecodeString(payload); err != nil {
+			return ErrDataURI
+		}
+		return nil
+	}
+	data, err := url.PathUnescape(payload)
+	if err != nil {
+		return ErrDataURI
+	}
+	if max >= 0 && int64(len(data)) > max {
+		return ErrDecodedSize
+	}
+	return nil
+}
+
+// matchMediaType reports whether the lower case media type t is one of
+// types, which may end in "/*" to match a whole top-level type.
+func matchMediaType(t string, types []string) bool {
+	for _, allowed := range types {
+		if allowed == t || strings.HasSuffix(allowed, "/*") && strings.HasPrefix(t, allowed[:len(allowed)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxdecoded tests whether an encoded string decodes to at most the size
+// given as first parameter, e.g. maxdecoded=5MB, without decoding it, so
+// that oversized payloads are turned down before anything is allocated.
+// Data URIs are recognized by their "data:" scheme, other strings are
+// taken as base64 unless the second parameter is hex. Only the size is
+// checked, combine it with base64, hex or datauri to check the encoding.
+func maxdecoded(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	params := SplitParams(param)
+	if len(params) == 0 || len(params) > 2 {
+		return ErrBadParameter
+	}
+	max, err := asByteSize(params[0])
+	if err != nil {
+		return err
+	}
+	isHex := false
+	if len(params) == 2 {
+		switch params[1] {
+		case "base64":
+		case "hex":
+			isHex = true
+		default:
+			return ErrBadParameter
+		}
+	}
+
+	var size int64
+	switch {
+	case len(s) >= len("data:") && strings.EqualFold(s[:len("data:")], "data:"):
+		comma := strings.IndexByte(s, ',')
+		if comma < 0 {
+			return nil
+		}
+		meta, payload := s[:comma], s[comma+1:]
+		if strings.HasSuffix(strings.ToLower(meta), ";base64") {
+			size = base64DataLen(payload)
+		} else {
+			// each %XX escape decodes to one byte
+			size = int64(len(payload) - 2*strings.Count(payload, "%"))
+		}
+	case isHex:
+		size = int64(len(s) / 2)
+	default:
+		size = base64DataLen(s)
+	}
+	if size > max {
+		return ErrDecodedSize
+	}
+	return nil
+}
+
+// base64DataLen is base64DecodedLen for base64 that may be broken into
+// lines, as in MIME.
+func base64DataLen(s string) int64 {
+	var n int64
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c != '=' && c != '\r' && c != '\n' {
+			n++
+		}
+	}
+	return n * 6 / 8
+}
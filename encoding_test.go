@@ -0,0 +1,160 @@
+package govalidator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAsByteSize(t *testing.T) {
+	tests := map[string]int64{"0": 0, "512": 512, "10B": 10, "1KB": 1024, "2 MiB": 2 << 20, "1GB": 1 << 30, "3M": 3 << 20}
+	for param, want := range tests {
+		if n, err := asByteSize(param); err != nil || n != want {
+			t.Errorf("%q: expected %d, got %d %v", param, want, n, err)
+		}
+	}
+	for _, param := range []string{"", "MB", "-1KB", "1.5MB", "1TB"} {
+		if _, err := asByteSize(param); err != ErrBadParameter {
+			t.Errorf("%q: expected ErrBadParameter, got %v", param, err)
+		}
+	}
+}
+
+func TestBase64AndHex(t *testing.T) {
+	big := strings.Repeat("QUJD", 400) // 1200 bytes
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{base64Std, "aGVsbG8=", "", nil},
+		{base64Std, "aGVsbG8", "", ErrBase64},
+		{base64Std, "aGV*bG8=", "", ErrBase64},
+		{base64Std, big, "", nil},
+		{base64URL, "_-8", "", nil},
+		{base64URL, "_-8=", "", nil},
+		{base64URL, "/+8=", "", ErrBase64},
+		{base64Raw, "aGVsbG8", "", nil},
+		{base64Raw, "aGVsbG8=", "", ErrBase64},
+		{hexString, "deadBEEF", "", nil},
+		{hexString, "abc", "", ErrHex},
+		{hexString, "0xab", "", ErrHex},
+		{hexString, "", "", nil},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+}
+
+func TestDataURI(t *testing.T) {
+	png := "data:image/png;base64,iVBORw0KGgo="
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{png, "", nil},
+		{"", "", nil},
+		{"DATA:image/PNG;BASE64,iVBORw0KGgo=", "image/png", nil},
+		{"data:,Hello%2C%20World%21", "", nil},
+		{"data:;charset=utf-8,caf%C3%A9", "text/plain", nil},
+		{"data:text/plain;charset=utf-8;base64,SGVsbG8", "", nil},
+		{png, "image/png,image/jpeg", nil},
+		{png, "image/*", nil},
+		{png, "image/jpeg", ErrDataURIType},
+		{png, "text/*", ErrDataURIType},
+		{png, "max:8", nil},
+		{png, "max:7", ErrDecodedSize},
+		{"data:,abc%20def", "max:6", ErrDecodedSize},
+		{png, "image/png,max:1KB", nil},
+		{"image/png;base64,iVBORw0KGgo=", "", ErrDataURI},
+		{"data:image/png;base64", "", ErrDataURI},
+		{"data:image/png;base64,not base64!", "", ErrDataURI},
+		{"data:image;base64,iVBORw0KGgo=", "", ErrDataURI},
+		{"data:,100%", "", ErrDataURI},
+		{png, "png", ErrBadParameter},
+		{png, "max:big", ErrBadParameter},
+		{1, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := dataURI(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+type Attachment64 struct {
+	Data string `valid:"maxdecoded=1KB"`
+	Hex  string `valid:"maxdecoded=4,hex"`
+}
+
+func TestMaxDecoded(t *testing.T) {
+	kb := strings.Repeat("A", 1368) // 1026 bytes
+	lines := strings.Repeat(strings.Repeat("A", 76)+"\r\n", 10)
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"aGVsbG8=", "5", nil},
+		{"aGVsbG8=", "4", ErrDecodedSize},
+		{"aGVsbG8", "5", nil},
+		{lines, "570", nil},
+		{lines, "569", ErrDecodedSize},
+		{kb, "1KB", ErrDecodedSize},
+		{kb, "2KB", nil},
+		{"deadbeef", "4,hex", nil},
+		{"deadbeef00", "4,hex", ErrDecodedSize},
+		{"deadbeef", "6,base64", nil},
+		{"data:image/png;base64,aGVsbG8=", "5", nil},
+		{"data:image/png;base64,aGVsbG8=", "4", ErrDecodedSize},
+		{"DATA:,hello%20world", "11", nil},
+		{"data:,hello%20world", "10", ErrDecodedSize},
+		{"", "1", nil},
+		{"x", "", ErrBadParameter},
+		{"x", "1,base32", ErrBadParameter},
+		{"x", "1,hex,x", ErrBadParameter},
+		{"x", "lots", ErrBadParameter},
+		{1, "1", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := maxdecoded(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+
+	resp, _ := New().Validate(Attachment64{Data: kb, Hex: "0011223344"})
+	if resp["Data"] != ErrDecodedSize || resp["Hex"] != ErrDecodedSize {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func TestDigests(t *testing.T) {
+	tests := []struct {
+		fn  ValidateFunc
+		v   interface{}
+		err error
+	}{
+		{md5Digest, "d41d8cd98f00b204e9800998ecf8427e", nil},
+		{md5Digest, "D41D8CD98F00B204E9800998ECF8427E", nil},
+		{md5Digest, "D41d8cd98f00b204e9800998ecf8427e", ErrDigest},
+		{md5Digest, "d41d8cd98f00b204e9800998ecf8427", ErrDigest},
+		{md5Digest, "g41d8cd98f00b204e9800998ecf8427e", ErrDigest},
+		{sha1Digest, "da39a3ee5e6b4b0d3255bfef95601890afd80709", nil},
+		{sha1Digest, "d41d8cd98f00b204e9800998ecf8427e", ErrDigest},
+		{sha256Digest, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", nil},
+		{sha256Digest, "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", ErrDigest},
+		{sha384Digest, "38b060a751ac96384cd9327eb1b1e36a21fdb71114be07434c0cc7bf63f6e1da274edebfe76f65fbd51ad2f14898b95b", nil},
+		{sha512Digest, "cf83e1357eefb8bdf1542850d66d8007d620e4050b5715dc83f4a921d36ce9ce47d0d13c5d85f2b0ff8318d2877eec2f63b931bd47417a81a538327af927da3e", nil},
+		{sha512Digest, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", ErrDigest},
+		{sha256Digest, "", nil},
+		{sha256Digest, 1, ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, ""); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
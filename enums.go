@@ -0,0 +1,265 @@
+package govalidator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var ErrEnumUnavailable = errors.New("enum values unavailable")
+
+// EnumFunc returns the values of a set that lives outside the code, such
+// as the plans stored in a database, for enumfn.
+type EnumFunc func(ctx context.Context) ([]string, error)
+
+// EnumError reports the values an enum allows, with the labels
+// registered with RegisterEnumLabels, "" for values without one, so that
+// the message reads e.g. "must be one of: active, suspended". It unwraps
+// to ErrEnum.
+type EnumError struct {
+	Values []string
+	Labels []string
+}
+
+func (e *EnumError) Error() string {
+	items := make([]string, len(e.Values))
+	for i, v := range e.Values {
+		items[i] = v
+		if i < len(e.Labels) && e.Labels[i] != "" {
+			items[i] = fmt.Sprintf("%s (%s)", v, e.Labels[i])
+		}
+	}
+	return "must be one of: " + strings.Join(items, ", ")
+}
+
+func (e *EnumError) Unwrap() error {
+	return ErrEnum
+}
+
+func RegisterEnum(name string, values []string) {
+	defaultValidator.RegisterEnum(name, values)
+}
+
+// RegisterEnum registers a set of values that tags can reference as
+// enum=@name, enumci=@name or eachenum=@name, so that it's defined in
+// one place, e.g. loaded from configuration. Empty values remove the
+// set.
+func (d *Validator) RegisterEnum(name string, values []string) {
+	if name == "" {
+		return
+	}
+	if len(values) == 0 {
+		delete(d.enums, name)
+		return
+	}
+	if d.enums == nil {
+		d.enums = map[string][]string{}
+	}
+	d.enums[name] = append([]string(nil), values...)
+}
+
+func RegisterEnumLabels(name string, labels map[string]string) {
+	defaultValidator.RegisterEnumLabels(name, labels)
+}
+
+// RegisterEnumLabels registers human readable labels for the values of
+// the set registered as name, listed by the *EnumError of a value out of
+// it, e.g. "must be one of: active (Active), suspended (On hold)". Empty
+// labels remove them.
+func (d *Validator) RegisterEnumLabels(name string, labels map[string]string) {
+	if name == "" {
+		return
+	}
+	if len(labels) == 0 {
+		delete(d.enumLabels, name)
+		return
+	}
+	if d.enumLabels == nil {
+		d.enumLabels = map[string]map[string]string{}
+	}
+	copied := make(map[string]string, len(labels))
+	for v, l := range labels {
+		copied[v] = l
+	}
+	d.enumLabels[name] = copied
+}
+
+// RegisterEnumFromStringer registers the String() forms of values, the
+// constants of an enum type with a generated String method, under name,
+// e.g. RegisterEnumFromStringer("status", StatusValues()...), so that the
+// tags and the Go constants can't drift apart. For other validators use
+// v.RegisterEnum(name, EnumStrings(values...)).
+func RegisterEnumFromStringer[T fmt.Stringer](name string, values ...T) {
+	defaultValidator.RegisterEnum(name, EnumStrings(values...))
+}
+
+// EnumStrings returns the String() forms of values.
+func EnumStrings[T fmt.Stringer](values ...T) []string {
+	items := make([]string, len(values))
+	for i, v := range values {
+		items[i] = v.String()
+	}
+	return items
+}
+
+// enumItems splits an enum parameter into the allowed values, replacing
+// the @name of registered sets with their values, e.g.
+// enum=@status,unknown. labels holds the label of each value, if any.
+func (d *Validator) enumItems(param string) (items, labels []string, err error) {
+	for _, item := range SplitParams(param) {
+		if !strings.HasPrefix(item, "@") {
+			items = append(items, item)
+			labels = append(labels, "")
+			continue
+		}
+		name := item[1:]
+		values, ok := d.enums[name]
+		if !ok {
+			return nil, nil, ErrBadParameter
+		}
+		for _, v := range values {
+			items = append(items, v)
+			labels = append(labels, d.enumLabels[name][v])
+		}
+	}
+	return items, labels, nil
+}
+
+// enumError turns the ErrEnum of a value out of items into an
+// *EnumError listing them.
+func enumError(err error, items, labels []string) error {
+	if err != ErrEnum {
+		return err
+	}
+	return &EnumError{Values: items, Labels: labels}
+}
+
+// typeEnum validates v against its own type, for enum without values:
+// through an IsValid() bool method or, failing that, a Values() []T
+// method listing the values of T, the pattern of enum generators. The
+// methods may have pointer receivers.
+func typeEnum(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil
+	}
+	receivers := []reflect.Value{rv}
+	if rv.Kind() != reflect.Ptr {
+		ptr := reflect.New(rv.Type())
+		ptr.Elem().Set(rv)
+		receivers = append(receivers, ptr)
+	}
+	for _, recv := range receivers {
+		m := recv.MethodByName("IsValid")
+		if m.IsValid() && m.Type().NumIn() == 0 && m.Type().NumOut() == 1 && m.Type().Out(0).Kind() == reflect.Bool {
+			if !m.Call(nil)[0].Bool() {
+				return ErrEnum
+			}
+			return nil
+		}
+	}
+	val := indirect(rv)
+	for _, recv := range receivers {
+		m := recv.MethodByName("Values")
+		if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+			continue
+		}
+		out := m.Type().Out(0)
+		if out.Kind() != reflect.Slice || out.Elem() != val.Type() || !val.Type().Comparable() {
+			continue
+		}
+		values := m.Call(nil)[0]
+		items := make([]string, values.Len())
+		for i := range items {
+			if values.Index(i).Interface() == val.Interface() {
+				return nil
+			}
+			items[i] = fmt.Sprint(values.Index(i).Interface())
+		}
+		return &EnumError{Values: items}
+	}
+	return ErrBadParameter
+}
+
+// enum checks v against the values given as parameters or, without
+// any, against its type, see typeEnum. Out of a known list of values the
+// error is an *EnumError.
+func (d *Validator) enum(v interface{}, param string) error {
+	if strings.TrimSpace(param) == "" {
+		return typeEnum(v)
+	}
+	items, labels, err := d.enumItems(param)
+	if err != nil {
+		return err
+	}
+	return enumError(enumOf(v, items), items, labels)
+}
+
+// enumci is like enum for strings but ignores the case of the value and
+// the whitespace around it, e.g. enumci=Red,Green accepts " green".
+func (d *Validator) enumci(v interface{}, param string) error {
+	items, labels, err := d.enumItems(param)
+	if err != nil {
+		return err
+	}
+	return enumError(enumciOf(v, items), items, labels)
+}
+
+// eachenum is enum applied to every element of a slice, e.g.
+// eachenum=red,green,blue, reporting the first element out of the set
+// as an *ElementError.
+func (d *Validator) eachenum(v interface{}, param string) error {
+	return eachElem(v, param, d.enum)
+}
+
+func RegisterEnumFunc(name string, fn EnumFunc) {
+	defaultValidator.RegisterEnumFunc(name, fn)
+}
+
+// RegisterEnumFunc registers a function that tags can reference as
+// enumfn=name to load the allowed values at validation time. The values
+// are cached for five minutes; errors aren't. Each call is bounded by the
+// lookup timeout, see SetLookupTimeout. A nil fn removes it.
+func (d *Validator) RegisterEnumFunc(name string, fn EnumFunc) {
+	if name == "" {
+		return
+	}
+	d.enumCache = newLookupCache(defaultLookupTTL, defaultLookupEntries)
+	if fn == nil {
+		delete(d.enumFuncs, name)
+		return
+	}
+	if d.enumFuncs == nil {
+		d.enumFuncs = map[string]EnumFunc{}
+	}
+	d.enumFuncs[name] = fn
+}
+
+// enumfn is enum with the values returned by the EnumFunc registered as
+// the parameter, e.g. enumfn=planTiers. It fails with ErrEnumUnavailable
+// when the function does, or with the error of ctx once it's done.
+func (d *Validator) enumfn(ctx context.Context, v interface{}, param string) error {
+	fn, ok := d.enumFuncs[param]
+	if !ok {
+		return ErrBadParameter
+	}
+	var items []string
+	if e, ok := d.enumCache.get(param); ok {
+		items = e.val.([]string)
+	} else {
+		lookupCtx, cancel := d.lookupContext(ctx)
+		defer cancel()
+		values, err := fn(lookupCtx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return ErrEnumUnavailable
+		}
+		items = append([]string(nil), values...)
+		d.enumCache.set(param, items, nil)
+	}
+	return enumError(enumOf(v, items), items, nil)
+}
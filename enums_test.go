@@ -0,0 +1,280 @@
+package govalidator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type Membership struct {
+	Status   string   `valid:"enum=@status"`
+	Previous string   `valid:"enumci=@status,unknown"`
+	History  []string `valid:"eachenum=@status"`
+	Level    int      `valid:"enum=@levels"`
+}
+
+func TestRegisterEnum(t *testing.T) {
+	v := New()
+	v.RegisterEnum("status", []string{"active", "suspended", "deleted"})
+	v.RegisterEnum("levels", []string{"1", "2", "3"})
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{v.enum, "active", "@status", nil},
+		{v.enum, "archived", "@status", ErrEnum},
+		{v.enum, "archived", "@status,archived", nil},
+		{v.enum, 2, "@levels", nil},
+		{v.enum, 4, "@levels", ErrEnum},
+		{v.enum, "active", "@missing", ErrBadParameter},
+		{v.enum, "active", "active,suspended", nil},
+		{v.enumci, " Suspended", "@status", nil},
+		{v.enumci, "unknown", "@status", ErrEnum},
+		{v.eachenum, []string{"active", "deleted"}, "@status", nil},
+		{v.eachenum, []string{"active", "gone"}, "@status", ErrEnum},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); !errors.Is(err, tt.err) || err == nil && tt.err != nil {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+
+	resp, _ := v.Validate(Membership{Status: "active", Previous: "UNKNOWN", History: []string{"active", "x"}, Level: 5})
+	if len(resp) != 2 || !errors.Is(resp["History"], ErrEnum) || !errors.Is(resp["Level"], ErrEnum) {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	// sets can be replaced and removed
+	v.RegisterEnum("status", []string{"archived"})
+	if err := v.enum("active", "@status"); !errors.Is(err, ErrEnum) {
+		t.Fatalf("replaced: %v", err)
+	}
+	v.RegisterEnum("status", nil)
+	if err := v.enum("archived", "@status"); err != ErrBadParameter {
+		t.Fatalf("removed: %v", err)
+	}
+}
+
+type Color int
+
+const (
+	Red Color = iota + 1
+	Green
+)
+
+func (c Color) IsValid() bool { return c == Red || c == Green }
+
+type Tier string
+
+func (Tier) Values() []Tier { return []Tier{"free", "pro"} }
+
+type Region string
+
+func (r *Region) IsValid() bool { return *r == "eu" || *r == "us" }
+
+type Plain string
+
+type Theme struct {
+	Color  Color  `valid:"enum"`
+	Tier   *Tier  `valid:"enum"`
+	Region Region `valid:"enum"`
+	Tiers  []Tier `valid:"eachenum"`
+}
+
+func TestTypeEnum(t *testing.T) {
+	pro, gold := Tier("pro"), Tier("gold")
+	tests := []struct {
+		v   interface{}
+		err error
+	}{
+		{Red, nil},
+		{Color(3), ErrEnum},
+		{Tier("free"), nil},
+		{Tier("gold"), ErrEnum},
+		{&pro, nil},
+		{&gold, ErrEnum},
+		{(*Tier)(nil), nil},
+		{Region("eu"), nil},
+		{Region("asia"), ErrEnum},
+		{Plain("x"), ErrBadParameter},
+		{"x", ErrBadParameter},
+	}
+	v := New()
+	for i, tt := range tests {
+		if err := v.enum(tt.v, ""); !errors.Is(err, tt.err) || err == nil && tt.err != nil {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+
+	resp, _ := v.Validate(Theme{Color: 7, Tier: &gold, Region: "us", Tiers: []Tier{"pro", "x"}})
+	if len(resp) != 3 || resp["Color"] != ErrEnum || !errors.Is(resp["Tier"], ErrEnum) || !errors.Is(resp["Tiers"], ErrEnum) {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func (c Color) String() string {
+	switch c {
+	case Red:
+		return "red"
+	case Green:
+		return "green"
+	}
+	return fmt.Sprintf("Color(%d)", int(c))
+}
+
+type Paint struct {
+	Color string `valid:"enum=@colors"`
+}
+
+func TestRegisterEnumFromStringer(t *testing.T) {
+	if got := EnumStrings(Red, Green); len(got) != 2 || got[0] != "red" || got[1] != "green" {
+		t.Fatalf("EnumStrings: %v", got)
+	}
+	if got := EnumStrings[Color](); len(got) != 0 {
+		t.Fatalf("EnumStrings: %v", got)
+	}
+
+	RegisterEnumFromStringer("colors", Red, Green)
+	defer RegisterEnum("colors", nil)
+	resp, _ := Validate(Paint{Color: "green"})
+	if len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+	resp, _ = Validate(Paint{Color: "Color(3)"})
+	if err := resp["Color"]; err == nil || err.Error() != "must be one of: red, green" {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func TestEnumError(t *testing.T) {
+	v := New()
+	v.RegisterEnum("status", []string{"active", "suspended"})
+	v.RegisterEnumLabels("status", map[string]string{"suspended": "On hold"})
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		msg   string
+	}{
+		{v.enum, "x", "a,b", "must be one of: a, b"},
+		{v.enum, "x", "@status", "must be one of: active, suspended (On hold)"},
+		{v.enum, "x", "@status,any", "must be one of: active, suspended (On hold), any"},
+		{v.enumci, "x", "@status", "must be one of: active, suspended (On hold)"},
+		{v.enum, 5, "1,2", "must be one of: 1, 2"},
+		{v.enum, Tier("x"), "", "must be one of: free, pro"},
+		{v.enum, Color(9), "", "not allowed out of enum value"},
+		{v.eachenum, []string{"x"}, "@status", "element 0 (x): must be one of: active, suspended (On hold)"},
+	}
+	for i, tt := range tests {
+		err := tt.fn(tt.v, tt.param)
+		if !errors.Is(err, ErrEnum) || err.Error() != tt.msg {
+			t.Errorf("%d: expected %q, got %v", i, tt.msg, err)
+		}
+	}
+
+	var ee *EnumError
+	if err := v.enum("x", "@status"); !errors.As(err, &ee) || len(ee.Values) != 2 || ee.Labels[1] != "On hold" {
+		t.Fatalf("EnumError: %#v", err)
+	}
+	if err := v.enum("x", "@missing"); err != ErrBadParameter {
+		t.Fatalf("missing: %v", err)
+	}
+
+	v.RegisterEnumLabels("status", nil)
+	if err := v.enum("x", "@status"); err.Error() != "must be one of: active, suspended" {
+		t.Fatalf("removed labels: %v", err)
+	}
+}
+
+type Subscription struct {
+	Plan string `valid:"enumfn=plans"`
+}
+
+func TestEnumFunc(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	calls := 0
+	plans := []string{"free", "pro"}
+	var fail error
+	d := New()
+	d.RegisterEnumFunc("plans", func(ctx context.Context) ([]string, error) {
+		calls++
+		if fail != nil {
+			return nil, fail
+		}
+		return plans, nil
+	})
+	ctx := context.Background()
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"pro", "plans", nil},
+		{"gold", "plans", ErrEnum},
+		{(*string)(nil), "plans", nil},
+		{"pro", "missing", ErrBadParameter},
+		{5, "plans", ErrBadParameter},
+	}
+	for i, tt := range tests {
+		if err := d.enumfn(ctx, tt.v, tt.param); !errors.Is(err, tt.err) || err == nil && tt.err != nil {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected one call, got %d", calls)
+	}
+	if err := d.enumfn(ctx, "gold", "plans"); err == nil || err.Error() != "must be one of: free, pro" {
+		t.Fatalf("message: %v", err)
+	}
+
+	// the values are cached until they expire
+	plans = []string{"free", "pro", "gold"}
+	if err := d.enumfn(ctx, "gold", "plans"); !errors.Is(err, ErrEnum) {
+		t.Fatalf("cached: %v", err)
+	}
+	now = now.Add(defaultLookupTTL + time.Second)
+	if err := d.enumfn(ctx, "gold", "plans"); err != nil || calls != 2 {
+		t.Fatalf("expired: %v, %d calls", err, calls)
+	}
+	if resp, _ := d.ValidateContext(ctx, Subscription{Plan: "team"}); !errors.Is(resp["Plan"], ErrEnum) {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	// failures aren't cached
+	now = now.Add(defaultLookupTTL + time.Second)
+	fail = errors.New("db down")
+	if err := d.enumfn(ctx, "pro", "plans"); err != ErrEnumUnavailable {
+		t.Fatalf("failing: %v", err)
+	}
+	fail = nil
+	if err := d.enumfn(ctx, "pro", "plans"); err != nil {
+		t.Fatalf("recovered: %v", err)
+	}
+
+	// calls are bounded by the lookup timeout and the caller's context
+	d.SetLookupTimeout(10 * time.Millisecond)
+	d.RegisterEnumFunc("slow", func(ctx context.Context) ([]string, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if err := d.enumfn(ctx, "pro", "slow"); err != ErrEnumUnavailable {
+		t.Fatalf("slow: %v", err)
+	}
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := d.enumfn(cancelled, "pro", "slow"); err != context.Canceled {
+		t.Fatalf("cancelled: %v", err)
+	}
+
+	d.RegisterEnumFunc("plans", nil)
+	if err := d.enumfn(ctx, "pro", "plans"); err != ErrBadParameter {
+		t.Fatalf("removed: %v", err)
+	}
+}
@@ -0,0 +1,336 @@
+package govalidator
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+var (
+	ErrUnsafePath  = errors.New("unsafe path")
+	ErrFile        = errors.New("not an existing regular file")
+	ErrDir         = errors.New("not an existing directory")
+	ErrNotReadable = errors.New("not readable")
+	ErrExt         = errors.New("file extension not allowed")
+	ErrMIME        = errors.New("content type not allowed")
+	ErrGlob        = errors.New("invalid glob pattern")
+	ErrGlobMatch   = errors.New("does not match the pattern")
+)
+
+// safepath tests whether a string is a clean relative path, safe to join
+// to a base directory or use as an object key: no "..", "." or empty
+// segments, no leading slash, backslash or drive letter and no NUL
+// bytes. Backslashes count as separators. With the parameter name it must
+// be a single file name, without separators.
+func safepath(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	nameOnly := false
+	switch param {
+	case "":
+	case "name":
+		nameOnly = true
+	default:
+		return ErrBadParameter
+	}
+	if strings.IndexByte(s, 0) >= 0 {
+		return ErrUnsafePath
+	}
+	p := strings.ReplaceAll(s, `\`, "/")
+	if nameOnly && strings.Contains(p, "/") {
+		return ErrUnsafePath
+	}
+	if len(p) >= 2 && isASCIILetter(rune(p[0])) && p[1] == ':' {
+		return ErrUnsafePath
+	}
+	if path.IsAbs(p) || path.Clean(p) != p || p == "." || p == ".." || strings.HasPrefix(p, "../") {
+		return ErrUnsafePath
+	}
+	return nil
+}
+
+func SetFS(fsys fs.FS) {
+	defaultValidator.SetFS(fsys)
+}
+
+// SetFS sets the file system the file, dir and readable rules look paths
+// up in, such as an fstest.MapFS in tests or an os.DirFS jailing them to
+// a directory. Paths are then taken relative to its root, a leading slash
+// is ignored and ".." isn't allowed. A nil fsys, the default, looks paths
+// up in the operating system's file system as they are.
+func (d *Validator) SetFS(fsys fs.FS) {
+	d.fsys = fsys
+}
+
+// fsPath returns s as a path of d.fsys.
+func fsPath(s string) (string, bool) {
+	p := strings.TrimLeft(filepath.ToSlash(s), "/")
+	if p == "" {
+		p = "."
+	}
+	return p, fs.ValidPath(p)
+}
+
+func (d *Validator) stat(s string) (fs.FileInfo, error) {
+	if d.fsys == nil {
+		return os.Stat(s)
+	}
+	p, ok := fsPath(s)
+	if !ok {
+		return nil, fs.ErrInvalid
+	}
+	return fs.Stat(d.fsys, p)
+}
+
+// file tests whether a string is the path of an existing regular file,
+// following symbolic links. See SetFS.
+func (d *Validator) file(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	fi, err := d.stat(s)
+	if err != nil || !fi.Mode().IsRegular() {
+		return ErrFile
+	}
+	return nil
+}
+
+// dir tests whether a string is the path of an existing directory.
+func (d *Validator) dir(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	fi, err := d.stat(s)
+	if err != nil || !fi.IsDir() {
+		return ErrDir
+	}
+	return nil
+}
+
+// readable tests whether a string is the path of a file or directory
+// that can be opened for reading.
+func (d *Validator) readable(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	var f fs.File
+	if d.fsys == nil {
+		f, err = os.Open(s)
+	} else if p, ok := fsPath(s); ok {
+		f, err = d.fsys.Open(p)
+	} else {
+		err = fs.ErrInvalid
+	}
+	if err != nil {
+		return ErrNotReadable
+	}
+	f.Close()
+	return nil
+}
+
+// ext tests whether the file name, or the last element of the path, held
+// by a string has one of the extensions given as parameters, compared
+// case-insensitively, e.g. ext=.png,.jpg,.pdf. Extensions may have more
+// than one part, like .tar.gz. With the parameter strict everything after
+// the first dot of the name must be an allowed extension, rejecting
+// double extensions such as "invoice.pdf.exe" or "shell.php.jpg".
+func ext(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	strict := false
+	var exts []string
+	for _, p := range SplitParams(param) {
+		if p == "strict" {
+			strict = true
+			continue
+		}
+		p = strings.ToLower(p)
+		if !strings.HasPrefix(p, ".") {
+			p = "." + p
+		}
+		if len(p) == 1 || strings.ContainsAny(p, `/\`) {
+			return ErrBadParameter
+		}
+		exts = append(exts, p)
+	}
+	if len(exts) == 0 {
+		return ErrBadParameter
+	}
+	name := strings.ToLower(s[strings.LastIndexAny(s, `/\`)+1:])
+	// the dot of hidden files, as in ".env", doesn't start an extension
+	stem := strings.TrimLeft(name, ".")
+	for _, e := range exts {
+		if !strings.HasSuffix(stem, e) || len(stem) == len(e) {
+			continue
+		}
+		if !strict || !strings.Contains(stem[:len(stem)-len(e)], ".") {
+			return nil
+		}
+	}
+	return ErrExt
+}
+
+// bytesValue returns the content of a []byte, or a named type of it.
+// ok is false for nil pointers and empty slices.
+func bytesValue(v interface{}) (b []byte, ok bool, err error) {
+	st := reflect.ValueOf(v)
+	if st.Kind() == reflect.Ptr {
+		if st.IsNil() {
+			return nil, false, nil
+		}
+		st = st.Elem()
+	}
+	if st.Kind() != reflect.Slice || st.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false, ErrUnsupported
+	}
+	if st.Len() == 0 {
+		return nil, false, nil
+	}
+	return st.Bytes(), true, nil
+}
+
+// magicTypes are signatures of formats http.DetectContentType doesn't
+// know, checked before it. offset is where the signature starts.
+var magicTypes = []struct {
+	offset    int
+	signature string
+	mediaType string
+}{
+	{0, "II*\x00", "image/tiff"},
+	{0, "MM\x00*", "image/tiff"},
+	{4, "ftypheic", "image/heic"},
+	{4, "ftypheix", "image/heic"},
+	{4, "ftypmif1", "image/heif"},
+	{4, "ftypavif", "image/avif"},
+	{0, "7z\xbc\xaf\x27\x1c", "application/x-7z-compressed"},
+	{0, "\xfd7zXZ\x00", "application/x-xz"},
+	{0, "\x28\xb5\x2f\xfd", "application/zstd"},
+	{0, "BZh", "application/x-bzip2"},
+}
+
+// sniffContentType returns the media type of b, without parameters,
+// from its first bytes.
+func sniffContentType(b []byte) string {
+	for _, m := range magicTypes {
+		if len(b) >= m.offset && bytes.HasPrefix(b[m.offset:], []byte(m.signature)) {
+			return m.mediaType
+		}
+	}
+	t := http.DetectContentType(b)
+	if i := strings.IndexByte(t, ';'); i >= 0 {
+		t = t[:i]
+	}
+	return t
+}
+
+// mimeType tests whether the content of a []byte is of one of the media
+// types given as parameters, sniffed from its first bytes rather than
+// trusting a file name or header, e.g. mime=image/png,image/jpeg or
+// mime=image/*.
+func mimeType(v interface{}, param string) error {
+	b, ok, err := bytesValue(v)
+	if !ok {
+		return err
+	}
+	types := SplitParams(param)
+	if len(types) == 0 {
+		return ErrBadParameter
+	}
+	for i, t := range types {
+		if !strings.Contains(t, "/") {
+			return ErrBadParameter
+		}
+		types[i] = strings.ToLower(t)
+	}
+	if !matchMediaType(sniffContentType(b), types) {
+		return ErrMIME
+	}
+	return nil
+}
+
+// validGlob reports whether pattern is a slash separated path.Match
+// pattern whose segments may also be "**", matching any number of
+// segments.
+func validGlob(pattern string) bool {
+	for _, seg := range strings.Split(pattern, "/") {
+		if seg == "**" {
+			continue
+		}
+		if _, err := path.Match(seg, ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// matchGlob reports whether name matches a pattern of validGlob, segment
+// by segment.
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// glob tests whether a string is a valid glob pattern: the syntax of
+// path.Match, '*', '?', '[...]' and '\' escapes, within slash separated
+// segments, and "**" segments matching any number of them.
+func glob(v interface{}, param string) error {
+	return checkString(v, validGlob, ErrGlob)
+}
+
+// matchesglob tests whether a string, a slash separated path, matches
+// one of the glob patterns given as parameters, e.g.
+// matchesglob=logs/**/*.json.
+func matchesglob(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	patterns := SplitParams(param)
+	if len(patterns) == 0 {
+		return ErrBadParameter
+	}
+	for _, p := range patterns {
+		if !validGlob(p) {
+			return ErrBadParameter
+		}
+	}
+	for _, p := range patterns {
+		if matchGlob(p, s) {
+			return nil
+		}
+	}
+	return ErrGlobMatch
+}
@@ -0,0 +1,242 @@
+package govalidator
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestSafePath(t *testing.T) {
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"report.pdf", "", nil},
+		{"users/42/avatar.png", "", nil},
+		{"a..b/c", "", nil},
+		{".hidden", "", nil},
+		{"../secret", "", ErrUnsafePath},
+		{"a/../../b", "", ErrUnsafePath},
+		{"a/..", "", ErrUnsafePath},
+		{"..", "", ErrUnsafePath},
+		{".", "", ErrUnsafePath},
+		{"./a", "", ErrUnsafePath},
+		{"a//b", "", ErrUnsafePath},
+		{"a/", "", ErrUnsafePath},
+		{"/etc/passwd", "", ErrUnsafePath},
+		{`\\server\share`, "", ErrUnsafePath},
+		{`..\windows`, "", ErrUnsafePath},
+		{`C:\Windows`, "", ErrUnsafePath},
+		{"c:file", "", ErrUnsafePath},
+		{"a\x00.png", "", ErrUnsafePath},
+		{"report.pdf", "name", nil},
+		{"users/report.pdf", "name", ErrUnsafePath},
+		{`users\report.pdf`, "name", ErrUnsafePath},
+		{"", "", nil},
+		{(*string)(nil), "", nil},
+		{"a", "dir", ErrBadParameter},
+		{1, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := safepath(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %q: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+type DaemonConfig struct {
+	ConfigFile string `valid:"file;readable"`
+	DataDir    string `valid:"dir"`
+}
+
+func TestFileRules(t *testing.T) {
+	d := New()
+	d.SetFS(fstest.MapFS{
+		"etc/app.conf":  {Data: []byte("x")},
+		"var/lib/app":   {Mode: fs.ModeDir},
+		"var/run/sock":  {Mode: fs.ModeSocket},
+		"srv/empty.txt": {},
+	})
+	tests := []struct {
+		fn  ValidateFunc
+		v   interface{}
+		err error
+	}{
+		{d.file, "/etc/app.conf", nil},
+		{d.file, "etc/app.conf", nil},
+		{d.file, "srv/empty.txt", nil},
+		{d.file, "/var/lib/app", ErrFile},
+		{d.file, "/var/run/sock", ErrFile},
+		{d.file, "/etc/missing.conf", ErrFile},
+		{d.file, "/etc/../etc/app.conf", ErrFile},
+		{d.dir, "/var/lib/app", nil},
+		{d.dir, "/etc", nil},
+		{d.dir, "/", nil},
+		{d.dir, "/etc/app.conf", ErrDir},
+		{d.dir, "/opt", ErrDir},
+		{d.readable, "/etc/app.conf", nil},
+		{d.readable, "/var/lib/app", nil},
+		{d.readable, "/etc/missing.conf", ErrNotReadable},
+		{d.readable, "../etc/app.conf", ErrNotReadable},
+		{d.file, "", nil},
+		{d.dir, 1, ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, ""); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+
+	// the operating system's file system by default
+	tmp := t.TempDir()
+	conf := filepath.Join(tmp, "app.conf")
+	if err := os.WriteFile(conf, []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	d = New()
+	resp, _ := d.Validate(DaemonConfig{ConfigFile: conf, DataDir: tmp})
+	if len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+	resp, _ = d.Validate(DaemonConfig{ConfigFile: tmp, DataDir: conf})
+	if resp["ConfigFile"] != ErrFile || resp["DataDir"] != ErrDir {
+		t.Fatalf("resp: %v", resp)
+	}
+	if err := d.readable(filepath.Join(tmp, "missing"), ""); err != ErrNotReadable {
+		t.Fatalf("readable: %v", err)
+	}
+}
+
+func TestExt(t *testing.T) {
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"photo.png", ".png,.jpg,.pdf", nil},
+		{"PHOTO.JPG", ".png,.jpg,.pdf", nil},
+		{"scan.pdf", "png,jpg,PDF", nil},
+		{"uploads/2024/scan.pdf", ".pdf", nil},
+		{`C:\Users\me\scan.pdf`, ".pdf", nil},
+		{"invoice.pdf.exe", ".pdf", ErrExt},
+		{"archive.tar.gz", ".tar.gz", nil},
+		{"archive.tar.gz", ".gz", nil},
+		{"notes.txt", ".pdf", ErrExt},
+		{"pdf", ".pdf", ErrExt},
+		{".pdf", ".pdf", ErrExt},
+		{"..pdf", ".pdf", ErrExt},
+		{".env.pdf", ".pdf,strict", nil},
+		{"report.final.pdf", ".pdf", nil},
+		{"report.final.pdf", ".pdf,strict", ErrExt},
+		{"shell.php.jpg", "strict,.jpg", ErrExt},
+		{"archive.tar.gz", ".tar.gz,strict", nil},
+		{"archive.tar.gz", ".gz,strict", ErrExt},
+		{"", ".pdf", nil},
+		{"a.pdf", "", ErrBadParameter},
+		{"a.pdf", "strict", ErrBadParameter},
+		{"a.pdf", ".", ErrBadParameter},
+		{1, ".pdf", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := ext(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %q: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+type Avatar struct {
+	Image []byte `valid:"mime=image/png,image/jpeg"`
+}
+
+func TestMIME(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	jpeg := []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00")
+	heic := []byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00")
+	tiff := []byte("II*\x00\x08\x00\x00\x00")
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{png, "image/png,image/jpeg", nil},
+		{jpeg, "image/png,image/jpeg", nil},
+		{jpeg, "image/*", nil},
+		{heic, "image/heic", nil},
+		{heic, "image/*", nil},
+		{tiff, "image/tiff", nil},
+		{[]byte("%PDF-1.7\n"), "application/pdf", nil},
+		{[]byte("%PDF-1.7\n"), "image/*", ErrMIME},
+		{[]byte("<html><script>"), "image/png", ErrMIME},
+		{[]byte("hello"), "text/plain", nil},
+		{[]byte("7z\xbc\xaf\x27\x1c\x00\x04"), "application/x-7z-compressed", nil},
+		{[]byte("plain"), "IMAGE/*,Text/Plain", nil},
+		{&png, "image/png", nil},
+		{(*[]byte)(nil), "image/png", nil},
+		{[]byte{}, "image/png", nil},
+		{png, "", ErrBadParameter},
+		{png, "png", ErrBadParameter},
+		{"\x89PNG\r\n\x1a\n", "image/png", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := mimeType(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+
+	resp, _ := New().Validate(Avatar{Image: []byte("GIF89a")})
+	if resp["Image"] != ErrMIME {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func TestGlob(t *testing.T) {
+	tests := []struct {
+		v   string
+		err error
+	}{
+		{"*.json", nil},
+		{"logs/**/*.json", nil},
+		{"**", nil},
+		{"data/[a-z]?.csv", nil},
+		{`a\*b`, nil},
+		{"", nil},
+		{"logs/[a-", ErrGlob},
+		{"logs/**/x[", ErrGlob},
+		{`trailing\`, ErrGlob},
+	}
+	for _, tt := range tests {
+		if err := glob(tt.v, ""); err != tt.err {
+			t.Errorf("%q: expected %v, got %v", tt.v, tt.err, err)
+		}
+	}
+}
+
+func TestMatchesGlob(t *testing.T) {
+	tests := []struct {
+		v     string
+		param string
+		err   error
+	}{
+		{"logs/app.json", "logs/**/*.json", nil},
+		{"logs/2024/01/app.json", "logs/**/*.json", nil},
+		{"logs/app.txt", "logs/**/*.json", ErrGlobMatch},
+		{"other/app.json", "logs/**/*.json", ErrGlobMatch},
+		{"logs/a/app.json", "logs/*.json", ErrGlobMatch},
+		{"a/b/c", "**", nil},
+		{"a/b/c", "a/**", nil},
+		{"a", "a/**", nil},
+		{"x.csv", "*.json,*.csv", nil},
+		{"x.xml", "*.json,*.csv", ErrGlobMatch},
+		{"", "*.json", nil},
+		{"x.json", "", ErrBadParameter},
+		{"x.json", "[a-", ErrBadParameter},
+	}
+	for _, tt := range tests {
+		if err := matchesglob(tt.v, tt.param); err != tt.err {
+			t.Errorf("%q, %q: expected %v, got %v", tt.v, tt.param, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package govalidator
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCreditCard = errors.New("invalid credit card number")
+	ErrCardBrand  = errors.New("card brand not allowed")
+	ErrIBAN       = errors.New("invalid iban")
+	ErrBIC        = errors.New("invalid bic")
+	ErrISIN       = errors.New("invalid isin")
+	ErrCUSIP      = errors.New("invalid cusip")
+
+	ErrAmount         = errors.New("invalid amount")
+	ErrAmountDecimals = errors.New("too many decimal places")
+)
+
+// iinRange is a range of issuer identification number prefixes written
+// with the same number of digits, e.g. "2221" to "2720".
+type iinRange struct {
+	lo, hi string
+}
+
+type cardBrand struct {
+	ranges  []iinRange
+	minLen  int
+	maxLen  int
+	lengths []int
+}
+
+// cardBrands are the IIN ranges and number lengths of the card brands
+// accepted as parameters of creditcard.
+var cardBrands = map[string]cardBrand{
+	"visa":       {ranges: []iinRange{{"4", "4"}}, lengths: []int{13, 16, 19}},
+	"mastercard": {ranges: []iinRange{{"51", "55"}, {"2221", "2720"}}, lengths: []int{16}},
+	"amex":       {ranges: []iinRange{{"34", "34"}, {"37", "37"}}, lengths: []int{15}},
+	"discover":   {ranges: []iinRange{{"6011", "6011"}, {"644", "649"}, {"65", "65"}, {"622126", "622925"}}, minLen: 16, maxLen: 19},
+	"dinersclub": {ranges: []iinRange{{"300", "305"}, {"36", "36"}, {"38", "39"}}, minLen: 14, maxLen: 19},
+	"jcb":        {ranges: []iinRange{{"3528", "3589"}}, minLen: 16, maxLen: 19},
+	"unionpay":   {ranges: []iinRange{{"62", "62"}}, minLen: 16, maxLen: 19},
+	"maestro":    {ranges: []iinRange{{"50", "50"}, {"56", "69"}}, minLen: 12, maxLen: 19},
+}
+
+func (b cardBrand) matches(number string) bool {
+	validLen := len(number) >= b.minLen && len(number) <= b.maxLen
+	for _, n := range b.lengths {
+		validLen = validLen || len(number) == n
+	}
+	if !validLen {
+		return false
+	}
+	for _, r := range b.ranges {
+		if len(number) >= len(r.lo) && number[:len(r.lo)] >= r.lo && number[:len(r.lo)] <= r.hi {
+			return true
+		}
+	}
+	return false
+}
+
+// creditcard tests whether a string is a payment card number of 12 to
+// 19 digits, optionally grouped with spaces or hyphens, that passes the
+// Luhn check. The parameters, if any, are the accepted brands, e.g.
+// creditcard=visa,mastercard, checked by IIN range and length.
+func creditcard(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	brands := SplitParams(param)
+	for _, name := range brands {
+		if _, ok := cardBrands[strings.ToLower(name)]; !ok {
+			return ErrBadParameter
+		}
+	}
+	number := strings.NewReplacer(" ", "", "-", "").Replace(s)
+	if len(number) < 12 || len(number) > 19 || !luhn(number) {
+		return ErrCreditCard
+	}
+	if len(brands) == 0 {
+		return nil
+	}
+	for _, name := range brands {
+		if cardBrands[strings.ToLower(name)].matches(number) {
+			return nil
+		}
+	}
+	return ErrCardBrand
+}
+
+// luhn reports whether s is made of digits and passes the Luhn check.
+func luhn(s string) bool {
+	sum := 0
+	for i := 0; i < len(s); i++ {
+		c := s[len(s)-1-i]
+		if !isASCIIDigit(rune(c)) {
+			return false
+		}
+		d := int(c - '0')
+		if i%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// ibanLengths are the IBAN lengths of the countries of the SWIFT IBAN
+// registry.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16,
+	"BG": 22, "BH": 22, "BI": 27, "BR": 29, "BY": 28, "CH": 21, "CR": 22,
+	"CY": 28, "CZ": 24, "DE": 22, "DJ": 27, "DK": 18, "DO": 28, "EE": 20,
+	"EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27, "GB": 22,
+	"GE": 22, "GI": 23, "GL": 18, "GR": 27, "GT": 28, "HN": 28, "HR": 21,
+	"HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27, "JO": 30,
+	"KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20,
+	"LV": 21, "LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20,
+	"MR": 27, "MT": 31, "MU": 30, "NI": 28, "NL": 18, "NO": 15, "OM": 23,
+	"PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22,
+	"RU": 33, "SA": 24, "SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24,
+	"SM": 27, "SO": 23, "ST": 25, "SV": 28, "TL": 23, "TN": 24, "TR": 26,
+	"UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+// iban tests whether a string is an International Bank Account Number,
+// in electronic form or printed in groups of four: a known country with
+// the length it uses and valid mod-97 check digits.
+func iban(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	s = strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+	if len(s) < 4 || ibanLengths[s[:2]] != len(s) || !isASCIIDigit(rune(s[2])) || !isASCIIDigit(rune(s[3])) {
+		return ErrIBAN
+	}
+	if mod97(s[4:]+s[:4]) != 1 {
+		return ErrIBAN
+	}
+	return nil
+}
+
+// mod97 returns the ISO 7064 MOD 97-10 remainder of s, letters counting
+// as 10 to 35, or -1 if s has anything but digits and upper case letters.
+func mod97(s string) int {
+	rem := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			rem = (rem*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			rem = (rem*100 + int(c-'A') + 10) % 97
+		default:
+			return -1
+		}
+	}
+	return rem
+}
+
+// bic tests whether a string is an ISO 9362 business identifier code,
+// also known as SWIFT code: 4 letters of institution, 2 of country, 2
+// letters or digits of location and an optional 3 of branch, all in
+// upper case.
+func bic(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if len(s) != 8 && len(s) != 11 {
+		return ErrBIC
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		upper := c >= 'A' && c <= 'Z'
+		if !upper && (i < 6 || c < '0' || c > '9') {
+			return ErrBIC
+		}
+	}
+	return nil
+}
+
+// isinPrefixes are the ISIN prefixes that aren't countries, such as XS
+// for international securities cleared through Euroclear or Clearstream.
+var isinPrefixes = map[string]bool{"XS": true, "EU": true, "XA": true, "XB": true, "XC": true, "XD": true}
+
+// isin tests whether a string is an ISO 6166 International Securities
+// Identification Number: an ISO 3166 country or international prefix,
+// 9 upper case letters or digits and a Luhn check digit.
+func isin(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if len(s) != 12 || !(countryCodes[s[:2]] || isinPrefixes[s[:2]]) || !isASCIIDigit(rune(s[11])) {
+		return ErrISIN
+	}
+	var digits strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			digits.WriteByte(c)
+		case c >= 'A' && c <= 'Z':
+			digits.WriteString(strconv.Itoa(int(c-'A') + 10))
+		default:
+			return ErrISIN
+		}
+	}
+	if !luhn(digits.String()) {
+		return ErrISIN
+	}
+	return nil
+}
+
+// cusip tests whether a string is a 9 character CUSIP: 8 upper case
+// letters, digits or "*@#" and a check digit.
+func cusip(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if len(s) != 9 || !isASCIIDigit(rune(s[8])) {
+		return ErrCUSIP
+	}
+	sum := 0
+	for i := 0; i < 8; i++ {
+		var d int
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			d = int(c - '0')
+		case c >= 'A' && c <= 'Z':
+			d = int(c-'A') + 10
+		case c == '*':
+			d = 36
+		case c == '@':
+			d = 37
+		case c == '#':
+			d = 38
+		default:
+			return ErrCUSIP
+		}
+		if i%2 == 1 {
+			d *= 2
+		}
+		sum += d/10 + d%10
+	}
+	if (10-sum%10)%10 != int(s[8]-'0') {
+		return ErrCUSIP
+	}
+	return nil
+}
+
+// parseAmount reports whether s is a plain decimal number such as
+// "1234.50" or "-0.5", without exponent, grouping or leading '+', and
+// returns the number of its fraction digits.
+func parseAmount(s string) (decimals int, negative, ok bool) {
+	if strings.HasPrefix(s, "-") {
+		s, negative = s[1:], true
+	}
+	intPart, frac := s, ""
+	if dot := strings.IndexByte(s, '.'); dot >= 0 {
+		intPart, frac = s[:dot], s[dot+1:]
+		if frac == "" {
+			return 0, false, false
+		}
+	}
+	if intPart == "" {
+		return 0, false, false
+	}
+	for _, part := range []string{intPart, frac} {
+		for i := 0; i < len(part); i++ {
+			if !isASCIIDigit(rune(part[i])) {
+				return 0, false, false
+			}
+		}
+	}
+	return len(frac), negative, true
+}
+
+// amount tests whether a string is a non-negative decimal amount such
+// as "1234.50". The parameters are the maximum number of decimal places,
+// e.g. amount=2, and signed to accept negative amounts.
+func amount(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	maxDecimals, signed := -1, false
+	for _, p := range SplitParams(param) {
+		if p == "signed" {
+			signed = true
+			continue
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return ErrBadParameter
+		}
+		maxDecimals = n
+	}
+	decimals, negative, ok := parseAmount(s)
+	if !ok || negative && !signed {
+		return ErrAmount
+	}
+	if maxDecimals >= 0 && decimals > maxDecimals {
+		return ErrAmountDecimals
+	}
+	return nil
+}
+
+// compareAmount compares the decimal amount in a string with param
+// exactly, without going through floating point.
+func compareAmount(v interface{}, param string, ruleErr error, reject func(cmp int) bool) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	bound, err := asBigRat(param)
+	if err != nil {
+		return err
+	}
+	if _, _, ok := parseAmount(s); !ok {
+		return ErrAmount
+	}
+	r, _ := new(big.Rat).SetString(s)
+	if reject(r.Cmp(bound)) {
+		return ruleErr
+	}
+	return nil
+}
+
+// amountmin tests whether a decimal amount string is at least param,
+// e.g. amountmin=0.01.
+func amountmin(v interface{}, param string) error {
+	return compareAmount(v, param, ErrMin, func(cmp int) bool { return cmp < 0 })
+}
+
+// amountmax tests whether a decimal amount string is at most param,
+// e.g. amountmax=99999.99.
+func amountmax(v interface{}, param string) error {
+	return compareAmount(v, param, ErrMax, func(cmp int) bool { return cmp > 0 })
+}
@@ -0,0 +1,150 @@
+package govalidator
+
+import "testing"
+
+func TestCreditCard(t *testing.T) {
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"4111111111111111", "", nil},
+		{"4111 1111 1111 1111", "", nil},
+		{"4111-1111-1111-1111", "visa", nil},
+		{"", "", nil},
+		{"4111111111111112", "", ErrCreditCard},
+		{"4111 1111 1111 111a", "", ErrCreditCard},
+		{"42", "", ErrCreditCard},
+		{"5555555555554444", "mastercard", nil},
+		{"2223003122003222", "mastercard", nil},
+		{"378282246310005", "amex", nil},
+		{"6011111111111117", "discover", nil},
+		{"30569309025904", "dinersclub", nil},
+		{"3530111333300000", "jcb", nil},
+		{"6200000000000005", "unionpay", nil},
+		{"4111111111111111", "visa,mastercard", nil},
+		{"5555555555554444", "visa,mastercard", nil},
+		{"378282246310005", "visa,mastercard", ErrCardBrand},
+		{"4222222222222", "visa", nil},
+		{"4111111111111111", "Visa", nil},
+		{"4111111111111111", "visa,paypal", ErrBadParameter},
+		{4111111111111111, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := creditcard(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+func TestIBANAndBIC(t *testing.T) {
+	tests := []struct {
+		fn  ValidateFunc
+		v   interface{}
+		err error
+	}{
+		{iban, "DE89370400440532013000", nil},
+		{iban, "GB82 WEST 1234 5698 7654 32", nil},
+		{iban, "gb82west12345698765432", nil},
+		{iban, "NO9386011117947", nil},
+		{iban, "FR1420041010050500013M02606", nil},
+		{iban, "", nil},
+		{iban, "DE89370400440532013001", ErrIBAN},
+		{iban, "DE8937040044053201300", ErrIBAN},
+		{iban, "US89370400440532013000", ErrIBAN},
+		{iban, "DEXX370400440532013000", ErrIBAN},
+		{iban, "GB82-WEST-1234-5698-7654-32", ErrIBAN},
+		{iban, "DE", ErrIBAN},
+		{bic, "DEUTDEFF", nil},
+		{bic, "DEUTDEFF500", nil},
+		{bic, "NEDSZAJJXXX", nil},
+		{bic, "BOFAUS3N", nil},
+		{bic, "", nil},
+		{bic, "deutdeff", ErrBIC},
+		{bic, "DEUTDEF", ErrBIC},
+		{bic, "DEU1DEFF", ErrBIC},
+		{bic, "DEUTD3FF", ErrBIC},
+		{bic, "DEUTDEFF50", ErrBIC},
+		{bic, "DEUTDEFF-00", ErrBIC},
+		{bic, 1, ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, ""); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+func TestSecurityIdentifiers(t *testing.T) {
+	tests := []struct {
+		fn  ValidateFunc
+		v   interface{}
+		err error
+	}{
+		{isin, "US0378331005", nil},
+		{isin, "AU0000XVGZA3", nil},
+		{isin, "GB0002634946", nil},
+		{isin, "XS2021832634", nil},
+		{isin, "", nil},
+		{isin, "US0378331006", ErrISIN},
+		{isin, "ZZ0378331005", ErrISIN},
+		{isin, "us0378331005", ErrISIN},
+		{isin, "US037833100", ErrISIN},
+		{isin, "US03783310-5", ErrISIN},
+		{cusip, "037833100", nil},
+		{cusip, "38259P508", nil},
+		{cusip, "594918104", nil},
+		{cusip, "037833101", ErrCUSIP},
+		{cusip, "03783310", ErrCUSIP},
+		{cusip, "03783310A", ErrCUSIP},
+		{cusip, "0378-3100", ErrCUSIP},
+		{cusip, 37833100, ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, ""); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+func TestAmount(t *testing.T) {
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{amount, "1234.50", "2", nil},
+		{amount, "0", "2", nil},
+		{amount, "007.5", "2", nil},
+		{amount, "1234.500", "2", ErrAmountDecimals},
+		{amount, "1234", "0", nil},
+		{amount, "1234.5", "0", ErrAmountDecimals},
+		{amount, "1.23456", "", nil},
+		{amount, "", "2", nil},
+		{amount, "-5.00", "2", ErrAmount},
+		{amount, "-5.00", "2,signed", nil},
+		{amount, "-5.001", "2,signed", ErrAmountDecimals},
+		{amount, "+5", "2", ErrAmount},
+		{amount, "5.", "2", ErrAmount},
+		{amount, ".5", "2", ErrAmount},
+		{amount, "1e3", "2", ErrAmount},
+		{amount, "1,000.00", "2", ErrAmount},
+		{amount, "NaN", "", ErrAmount},
+		{amount, "1", "two", ErrBadParameter},
+		{amountmax, "99999.99", "99999.99", nil},
+		{amountmax, "99999.990000001", "99999.99", ErrMax},
+		{amountmax, "100000", "99999.99", ErrMax},
+		{amountmax, "-100000", "99999.99", nil},
+		{amountmin, "0.01", "0.01", nil},
+		{amountmin, "0.009", "0.01", ErrMin},
+		{amountmin, "abc", "0.01", ErrAmount},
+		{amountmin, "1", "x", ErrBadParameter},
+		{amountmin, 1.5, "1", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package govalidator
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrLatitude       = errors.New("invalid latitude")
+	ErrLongitude      = errors.New("invalid longitude")
+	ErrCoordPrecision = errors.New("coordinate too precise")
+)
+
+// latitude tests whether a number, or a numeric string, is a latitude
+// between -90 and 90 degrees. The optional parameter is the maximum
+// number of decimal places, e.g. latitude=6 for about 10cm.
+func latitude(v interface{}, param string) error {
+	return checkCoordinate(v, param, 90, ErrLatitude)
+}
+
+// longitude tests whether a number, or a numeric string, is a longitude
+// between -180 and 180 degrees. It takes the parameter of latitude.
+func longitude(v interface{}, param string) error {
+	return checkCoordinate(v, param, 180, ErrLongitude)
+}
+
+func checkCoordinate(v interface{}, param string, limit float64, ruleErr error) error {
+	st := reflect.ValueOf(v)
+	if st.Kind() == reflect.Ptr {
+		if st.IsNil() {
+			return nil
+		}
+		st = st.Elem()
+	}
+	maxDecimals := -1
+	if param != "" {
+		n, err := strconv.Atoi(param)
+		if err != nil || n < 0 {
+			return ErrBadParameter
+		}
+		maxDecimals = n
+	}
+
+	var s string
+	switch st.Kind() {
+	case reflect.String:
+		if s = st.String(); s == "" {
+			return nil
+		}
+		if !isDecimal(s) {
+			return ruleErr
+		}
+	case reflect.Float32:
+		s = strconv.FormatFloat(st.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		s = strconv.FormatFloat(st.Float(), 'f', -1, 64)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s = strconv.FormatInt(st.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s = strconv.FormatUint(st.Uint(), 10)
+	default:
+		return ErrUnsupported
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || !(f >= -limit && f <= limit) {
+		return ruleErr
+	}
+	if _, frac, ok := strings.Cut(s, "."); ok && maxDecimals >= 0 && len(frac) > maxDecimals {
+		return ErrCoordPrecision
+	}
+	return nil
+}
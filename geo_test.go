@@ -0,0 +1,45 @@
+package govalidator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCoordinates(t *testing.T) {
+	var nilLat *float64
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{latitude, 52.520008, "", nil},
+		{latitude, -90.0, "", nil},
+		{latitude, 90, "", nil},
+		{latitude, "52.520008", "", nil},
+		{latitude, "-0.5", "", nil},
+		{latitude, "", "", nil},
+		{latitude, nilLat, "", nil},
+		{latitude, 90.0001, "", ErrLatitude},
+		{latitude, "-91", "", ErrLatitude},
+		{latitude, "52,5", "", ErrLatitude},
+		{latitude, "1e1", "", ErrLatitude},
+		{latitude, math.NaN(), "", ErrLatitude},
+		{latitude, 52.520008, "6", nil},
+		{latitude, 52.5200081, "6", ErrCoordPrecision},
+		{latitude, "52.5200000", "6", ErrCoordPrecision},
+		{latitude, float32(52.52), "2", nil},
+		{longitude, 13.404954, "", nil},
+		{longitude, -180, "", nil},
+		{longitude, "179.999999", "", nil},
+		{longitude, 180.5, "", ErrLongitude},
+		{longitude, uint8(200), "", ErrLongitude},
+		{longitude, 13.4, "two", ErrBadParameter},
+		{longitude, true, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package govalidator
+
+import (
+	"errors"
+	"html"
+	"strings"
+)
+
+var (
+	ErrHTML       = errors.New("markup not allowed")
+	ErrUnsafeHTML = errors.New("html not allowed by policy")
+)
+
+// HTMLPolicy sanitizes untrusted HTML, returning the markup it allows.
+// bluemonday's *Policy satisfies it.
+type HTMLPolicy interface {
+	Sanitize(s string) string
+}
+
+// HTMLPolicyFunc adapts a function to an HTMLPolicy.
+type HTMLPolicyFunc func(s string) string
+
+func (f HTMLPolicyFunc) Sanitize(s string) string {
+	return f(s)
+}
+
+// builtinHTMLPolicies are the policies known out of the box: strict
+// removes all markup.
+func builtinHTMLPolicies() map[string]HTMLPolicy {
+	return map[string]HTMLPolicy{
+		"strict": HTMLPolicyFunc(stripTags),
+	}
+}
+
+func SetHTMLPolicy(name string, p HTMLPolicy) {
+	defaultValidator.SetHTMLPolicy(name, p)
+}
+
+// SetHTMLPolicy registers a policy that tags can reference by name, in
+// the safehtml rule and sanitizer. A nil p removes it.
+func (d *Validator) SetHTMLPolicy(name string, p HTMLPolicy) {
+	if name == "" {
+		return
+	}
+	if p == nil {
+		delete(d.htmlPolicies, name)
+		return
+	}
+	d.htmlPolicies[name] = p
+}
+
+// markupAt returns the length of the tag, comment or character reference
+// at s[i], 0 if there is none. An unterminated tag runs to the end of s.
+func markupAt(s string, i int) int {
+	switch s[i] {
+	case '<':
+		if i+1 == len(s) {
+			return 0
+		}
+		c := s[i+1]
+		if !isASCIILetter(rune(c)) && c != '/' && c != '!' && c != '?' {
+			return 0
+		}
+		end := ">"
+		if strings.HasPrefix(s[i:], "<!--") {
+			end = "-->"
+		}
+		if n := strings.Index(s[i+1:], end); n >= 0 {
+			return 1 + n + len(end)
+		}
+		return len(s) - i
+	case '&':
+		n := strings.IndexByte(s[i:], ';')
+		if n < 2 || n > 32 {
+			return 0
+		}
+		ref := s[i+1 : i+n]
+		if ref[0] == '#' {
+			ref = strings.TrimPrefix(strings.TrimPrefix(ref[1:], "x"), "X")
+		}
+		for j := 0; j < len(ref); j++ {
+			if !isAlnum(ref[j]) {
+				return 0
+			}
+		}
+		if len(ref) == 0 {
+			return 0
+		}
+		return n + 1
+	}
+	return 0
+}
+
+// stripTags removes the tags and comments of s, and the content of
+// script and style elements, and escapes the remaining text.
+func stripTags(s string) string {
+	var b strings.Builder
+	text := 0
+	for i := 0; i < len(s); {
+		if s[i] != '<' {
+			i++
+			continue
+		}
+		n := markupAt(s, i)
+		if n == 0 {
+			i++
+			continue
+		}
+		b.WriteString(html.EscapeString(html.UnescapeString(s[text:i])))
+		tag := strings.ToLower(s[i : i+n])
+		i += n
+		for _, raw := range []string{"script", "style"} {
+			if strings.HasPrefix(tag, "<"+raw) && len(tag) > len(raw)+1 && !isASCIILetter(rune(tag[len(raw)+1])) {
+				if end := strings.Index(strings.ToLower(s[i:]), "</"+raw); end >= 0 {
+					i += end
+				} else {
+					i = len(s)
+				}
+			}
+		}
+		text = i
+	}
+	b.WriteString(html.EscapeString(html.UnescapeString(s[text:])))
+	return b.String()
+}
+
+// nohtml tests whether a string is free of markup: HTML tags, comments,
+// doctypes and character references such as "&lt;". A lone '<' or '&',
+// as in "a < b & c", is plain text.
+func nohtml(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	for i := 0; i < len(s); i++ {
+		if markupAt(s, i) > 0 {
+			return ErrHTML
+		}
+	}
+	return nil
+}
+
+// safehtml tests whether a string is left unchanged by the HTML policy
+// named as parameter, safehtml=strict by default, ignoring differences
+// in escaping. Use the safehtml sanitizer in the mod tag to rewrite the
+// field with the policy instead of rejecting it.
+func (d *Validator) safehtml(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if param == "" {
+		param = "strict"
+	}
+	p, ok := d.htmlPolicies[param]
+	if !ok {
+		return ErrBadParameter
+	}
+	if html.UnescapeString(p.Sanitize(s)) != html.UnescapeString(s) {
+		return ErrUnsafeHTML
+	}
+	return nil
+}
+
+// sanitizeHTML is the safehtml sanitizer, rewriting a field with the
+// named HTML policy. Fields naming an unknown policy are escaped whole.
+func (d *Validator) sanitizeHTML(s, param string) string {
+	if param == "" {
+		param = "strict"
+	}
+	p, ok := d.htmlPolicies[param]
+	if !ok {
+		return html.EscapeString(s)
+	}
+	return p.Sanitize(s)
+}
@@ -0,0 +1,117 @@
+package govalidator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNoHTML(t *testing.T) {
+	tests := []struct {
+		v   interface{}
+		err error
+	}{
+		{"plain text", nil},
+		{"a < b & c > d", nil},
+		{"Tom & Jerry; friends", nil},
+		{"1 <2", nil},
+		{"<b>bold</b>", ErrHTML},
+		{"x </p> y", ErrHTML},
+		{"<!-- hidden -->", ErrHTML},
+		{"<!DOCTYPE html>", ErrHTML},
+		{"<img src=x onerror=alert(1)", ErrHTML},
+		{"&lt;script&gt;", ErrHTML},
+		{"&#60;", ErrHTML},
+		{"&#x3C;", ErrHTML},
+		{"", nil},
+		{(*string)(nil), nil},
+		{3, ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := nohtml(tt.v, ""); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+func TestStripTags(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{"plain", "plain"},
+		{"<p>Hello <b>world</b></p>", "Hello world"},
+		{"a < b & c", "a &lt; b &amp; c"},
+		{"Tom &amp; Jerry", "Tom &amp; Jerry"},
+		{"hi<script>alert('x')</script>!", "hi!"},
+		{"<STYLE>p{}</STYLE>text", "text"},
+		{"<scripts>kept</scripts>", "kept"},
+		{"<!-- <b>gone</b> -->after", "after"},
+		{"x<script>never closed", "x"},
+		{"<img src=x onerror=alert(1)", ""},
+	}
+	for _, tt := range tests {
+		if got := stripTags(tt.in); got != tt.out {
+			t.Errorf("%q: expected %q, got %q", tt.in, tt.out, got)
+		}
+	}
+}
+
+type Comment struct {
+	Body    string `valid:"safehtml=ugc"`
+	Title   string `valid:"safehtml"`
+	Preview string `mod:"safehtml"`
+	Bio     string `mod:"safehtml=ugc"`
+}
+
+func TestSafeHTML(t *testing.T) {
+	d := New()
+	// allows <b> and <i>, strips everything else
+	ugc := HTMLPolicyFunc(func(s string) string {
+		for _, tag := range []string{"<b>", "</b>", "<i>", "</i>"} {
+			s = strings.ReplaceAll(s, tag, "\x00"+tag[1:len(tag)-1]+"\x01")
+		}
+		s = stripTags(s)
+		return strings.NewReplacer("\x00", "<", "\x01", ">").Replace(s)
+	})
+	d.SetHTMLPolicy("ugc", ugc)
+
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"<b>fine</b> & dandy", "ugc", nil},
+		{"<b>fine</b><script>x</script>", "ugc", ErrUnsafeHTML},
+		{"<a href=x>link</a>", "ugc", ErrUnsafeHTML},
+		{"no markup & all", "", nil},
+		{"<b>bold</b>", "", ErrUnsafeHTML},
+		{"", "ugc", nil},
+		{"x", "missing", ErrBadParameter},
+	}
+	for i, tt := range tests {
+		if err := d.safehtml(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+
+	c := Comment{
+		Body:    "<b>hi</b><img src=x>",
+		Title:   "ok",
+		Preview: "<p>Hello</p>",
+		Bio:     "<i>me</i><script>steal()</script>",
+	}
+	resp, err := d.Validate(&c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 1 || resp["Body"] != ErrUnsafeHTML {
+		t.Fatalf("resp: %v", resp)
+	}
+	if c.Preview != "Hello" || c.Bio != "<i>me</i>" {
+		t.Fatalf("sanitized: %q, %q", c.Preview, c.Bio)
+	}
+
+	d.SetHTMLPolicy("ugc", nil)
+	if got := d.sanitizeHTML("<b>x</b>", "ugc"); got != "&lt;b&gt;x&lt;/b&gt;" {
+		t.Fatalf("unknown policy: %q", got)
+	}
+}
@@ -0,0 +1,180 @@
+package govalidator
+
+import (
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUUID   = errors.New("invalid uuid")
+	ErrULID   = errors.New("invalid ulid")
+	ErrKSUID  = errors.New("invalid ksuid")
+	ErrNanoID = errors.New("invalid nanoid")
+)
+
+const (
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// maxKSUID is the base62 encoding of the largest 160 bit KSUID.
+	maxKSUID = "aWgEPTl1tmebfsQzFP4bxwgy80V"
+)
+
+// isUUID tests whether a value holds an RFC 4122 UUID, checking its
+// variant and version bits. It accepts strings in the canonical form
+// 8-4-4-4-12 of lower case hex digits and 16 byte arrays such as
+// uuid.UUID. The parameters are:
+//
+//	1-8     the required version, e.g. uuid=4
+//	braced  also accept strings wrapped in braces, "{...}"
+//	upper   also accept upper case hex digits
+//
+// The zero UUID and empty strings are left to nonzero.
+func isUUID(v interface{}, param string) error {
+	version, braced, upper := 0, false, false
+	for _, p := range SplitParams(param) {
+		switch p {
+		case "braced":
+			braced = true
+		case "upper":
+			upper = true
+		default:
+			n, err := strconv.Atoi(p)
+			if err != nil || n < 1 || n > 8 {
+				return ErrBadParameter
+			}
+			version = n
+		}
+	}
+
+	st := reflect.ValueOf(v)
+	if st.Kind() == reflect.Ptr {
+		if st.IsNil() {
+			return nil
+		}
+		st = st.Elem()
+	}
+	var b [16]byte
+	switch {
+	case st.Kind() == reflect.String:
+		s := st.String()
+		if s == "" {
+			return nil
+		}
+		if braced && strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			s = s[1 : len(s)-1]
+		}
+		if !upper && strings.ToLower(s) != s {
+			return ErrUUID
+		}
+		var ok bool
+		if b, ok = parseUUID(s); !ok {
+			return ErrUUID
+		}
+	case st.Kind() == reflect.Array && st.Len() == 16 && st.Type().Elem().Kind() == reflect.Uint8:
+		if st.IsZero() {
+			return nil
+		}
+		reflect.Copy(reflect.ValueOf(b[:]), st)
+	default:
+		return ErrUnsupported
+	}
+	return checkUUIDBytes(b, version)
+}
+
+// parseUUID decodes a UUID in the 8-4-4-4-12 form.
+func parseUUID(s string) (b [16]byte, ok bool) {
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return b, false
+	}
+	digits := s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	if _, err := hex.Decode(b[:], []byte(digits)); err != nil {
+		return b, false
+	}
+	return b, true
+}
+
+// checkUUIDBytes checks the variant and version bits of a UUID. A zero
+// version accepts any of the defined ones.
+func checkUUIDBytes(b [16]byte, version int) error {
+	v := int(b[6] >> 4)
+	if b[8]&0xc0 != 0x80 || v < 1 || v > 8 {
+		return ErrUUID
+	}
+	if version != 0 && v != version {
+		return ErrUUID
+	}
+	return nil
+}
+
+// isULID tests whether a string is a ULID: 26 characters of Crockford's
+// base32, in either case. The first character must not exceed '7' since
+// larger ones overflow the 48 bit millisecond timestamp.
+func isULID(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if len(s) != 26 || s[0] > '7' {
+		return ErrULID
+	}
+	for _, c := range strings.ToUpper(s) {
+		if !strings.ContainsRune(crockfordAlphabet, c) {
+			return ErrULID
+		}
+	}
+	return nil
+}
+
+// isKSUID tests whether a string is a KSUID: 27 base62 characters
+// encoding at most 160 bits.
+func isKSUID(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if len(s) != 27 {
+		return ErrKSUID
+	}
+	for i := 0; i < len(s); i++ {
+		if !isAlnum(s[i]) {
+			return ErrKSUID
+		}
+	}
+	// the base62 digits sort like their values, so a string
+	// comparison finds the ones that overflow
+	if s > maxKSUID {
+		return ErrKSUID
+	}
+	return nil
+}
+
+// isNanoID tests whether a string is a NanoID made of the default
+// A-Za-z0-9_- alphabet. The parameter is its length, 21 by default.
+func isNanoID(v interface{}, param string) error {
+	size := int64(21)
+	if param != "" {
+		var err error
+		if size, err = asInt(param); err != nil || size <= 0 {
+			return ErrBadParameter
+		}
+	}
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if int64(len(s)) != size {
+		return ErrNanoID
+	}
+	for i := 0; i < len(s); i++ {
+		if !isAlnum(s[i]) && s[i] != '_' && s[i] != '-' {
+			return ErrNanoID
+		}
+	}
+	return nil
+}
+
+func isAlnum(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+}
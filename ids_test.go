@@ -0,0 +1,95 @@
+package govalidator
+
+import "testing"
+
+// UUID mirrors the layout of github.com/google/uuid.UUID.
+type UUID [16]byte
+
+func TestUUIDArray(t *testing.T) {
+	v4 := UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x41, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	v1 := UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	bad := UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x41, 0xd1, 0xc0, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{nonzero, UUID{}, "", ErrZeroValue},
+		{nonzero, v4, "", nil},
+		{isUUID, v4, "", nil},
+		{isUUID, &v1, "", nil},
+		{isUUID, v4, "4", nil},
+		{isUUID, v1, "4", ErrUUID},
+		{isUUID, bad, "", ErrUUID},
+		{isUUID, UUID{}, "", nil},
+		{isUUID, [4]byte{}, "", ErrUnsupported},
+		{isUUID, v4, "x", ErrBadParameter},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+}
+
+func TestUUIDString(t *testing.T) {
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "", nil},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "1", nil},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "4", ErrUUID},
+		{"f47ac10b-58cc-4372-a567-0e02b2c3d479", "4", nil},
+		{"01890a5d-ac96-774b-bcce-b302099a8057", "7", nil},
+		{"F47AC10B-58CC-4372-A567-0E02B2C3D479", "", ErrUUID},
+		{"F47AC10B-58CC-4372-A567-0E02B2C3D479", "4,upper", nil},
+		{"{f47ac10b-58cc-4372-a567-0e02b2c3d479}", "", ErrUUID},
+		{"{f47ac10b-58cc-4372-a567-0e02b2c3d479}", "braced", nil},
+		{"f47ac10b58cc4372a5670e02b2c3d479", "", ErrUUID},
+		{"f47ac10b-58cc-4372-c567-0e02b2c3d479", "", ErrUUID},
+		{"g47ac10b-58cc-4372-a567-0e02b2c3d479", "", ErrUUID},
+		{"00000000-0000-0000-0000-000000000000", "", ErrUUID},
+		{"", "", nil},
+		{"f47ac10b-58cc-4372-a567-0e02b2c3d479", "9", ErrBadParameter},
+	}
+	for i, tt := range tests {
+		if err := isUUID(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+func TestIDFormats(t *testing.T) {
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{isULID, "01ARZ3NDEKTSV4RRFFQ69G5FAV", "", nil},
+		{isULID, "01arz3ndektsv4rrffq69g5fav", "", nil},
+		{isULID, "81ARZ3NDEKTSV4RRFFQ69G5FAV", "", ErrULID},
+		{isULID, "01ARZ3NDEKTSV4RRFFQ69G5FAU", "", ErrULID},
+		{isULID, "01ARZ3NDEKTSV4RRFFQ69G5FA", "", ErrULID},
+		{isKSUID, "0ujtsYcgvSTl8PAuAdqWYSMnLOv", "", nil},
+		{isKSUID, "aWgEPTl1tmebfsQzFP4bxwgy80V", "", nil},
+		{isKSUID, "aWgEPTl1tmebfsQzFP4bxwgy80W", "", ErrKSUID},
+		{isKSUID, "zzzzzzzzzzzzzzzzzzzzzzzzzzz", "", ErrKSUID},
+		{isKSUID, "0ujtsYcgvSTl8PAuAdqWYSMnLO-", "", ErrKSUID},
+		{isNanoID, "V1StGXR8_Z5jdHi6B-myT", "", nil},
+		{isNanoID, "V1StGXR8_Z5jdHi6B-myT", "21", nil},
+		{isNanoID, "V1StGXR8_Z", "10", nil},
+		{isNanoID, "V1StGXR8_Z", "", ErrNanoID},
+		{isNanoID, "V1StGXR8_Z5jdHi6B+myT", "", ErrNanoID},
+		{isNanoID, "V1StGXR8_Z", "0", ErrBadParameter},
+		{isNanoID, "", "", nil},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
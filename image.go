@@ -0,0 +1,117 @@
+package govalidator
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	_ "image/gif"  // register the GIF header decoder
+	_ "image/jpeg" // register the JPEG header decoder
+	_ "image/png"  // register the PNG header decoder
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrImage       = errors.New("invalid image")
+	ErrImageWidth  = errors.New("image width out of range")
+	ErrImageHeight = errors.New("image height out of range")
+	ErrImageRatio  = errors.New("wrong image aspect ratio")
+)
+
+// imageConfig decodes the header of the image held by a []byte or an
+// io.ReadSeeker, which is rewound to where it was so that the image can
+// still be read. Readers that can't seek are ErrUnsupported, as reading
+// their header would consume it. ok is false for nil and empty values.
+func imageConfig(v interface{}) (cfg image.Config, ok bool, err error) {
+	if v == nil {
+		return cfg, false, nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return cfg, false, nil
+	}
+	var r io.Reader
+	if rs, isReader := v.(io.ReadSeeker); isReader {
+		pos, err := rs.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return cfg, false, ErrImage
+		}
+		defer rs.Seek(pos, io.SeekStart)
+		r = rs
+	} else if _, isReader := v.(io.Reader); isReader {
+		return cfg, false, ErrUnsupported
+	} else {
+		b, ok, err := bytesValue(v)
+		if !ok {
+			return cfg, false, err
+		}
+		r = bytes.NewReader(b)
+	}
+	if cfg, _, err = image.DecodeConfig(r); err != nil {
+		return cfg, false, ErrImage
+	}
+	return cfg, true, nil
+}
+
+// checkImageSize compares the width or height of an image with the number
+// of pixels given as parameter using cmp.
+func checkImageSize(v interface{}, param string, height bool, cmp func(n, p int) bool, ruleErr error) error {
+	p, err := strconv.Atoi(param)
+	if err != nil || p < 0 {
+		return ErrBadParameter
+	}
+	cfg, ok, err := imageConfig(v)
+	if !ok {
+		return err
+	}
+	n := cfg.Width
+	if height {
+		n = cfg.Height
+	}
+	if !cmp(n, p) {
+		return ruleErr
+	}
+	return nil
+}
+
+// imgmaxw tests whether a PNG, JPEG or GIF image, a []byte or an
+// io.ReadSeeker, is at most the number of pixels given as parameter wide.
+// Only the image header is decoded.
+func imgmaxw(v interface{}, param string) error {
+	return checkImageSize(v, param, false, func(n, p int) bool { return n <= p }, ErrImageWidth)
+}
+
+// imgmaxh tests whether an image is at most param pixels high.
+func imgmaxh(v interface{}, param string) error {
+	return checkImageSize(v, param, true, func(n, p int) bool { return n <= p }, ErrImageHeight)
+}
+
+// imgminw tests whether an image is at least param pixels wide.
+func imgminw(v interface{}, param string) error {
+	return checkImageSize(v, param, false, func(n, p int) bool { return n >= p }, ErrImageWidth)
+}
+
+// imgminh tests whether an image is at least param pixels high.
+func imgminh(v interface{}, param string) error {
+	return checkImageSize(v, param, true, func(n, p int) bool { return n >= p }, ErrImageHeight)
+}
+
+// imgratio tests whether the width and height of an image are in the
+// ratio given as parameter, exactly, e.g. imgratio=1:1 or imgratio=16:9.
+func imgratio(v interface{}, param string) error {
+	w, h, ok := strings.Cut(param, ":")
+	rw, errW := strconv.Atoi(w)
+	rh, errH := strconv.Atoi(h)
+	if !ok || errW != nil || errH != nil || rw <= 0 || rh <= 0 {
+		return ErrBadParameter
+	}
+	cfg, ok, err := imageConfig(v)
+	if !ok {
+		return err
+	}
+	if int64(cfg.Width)*int64(rh) != int64(cfg.Height)*int64(rw) {
+		return ErrImageRatio
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package govalidator
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+)
+
+func pngOf(t *testing.T, w, h int) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+type ProfileImages struct {
+	Avatar []byte    `valid:"imgmaxw=512;imgmaxh=512;imgratio=1:1"`
+	Banner io.Reader `valid:"imgminw=1200;imgratio=3:1"`
+}
+
+func TestImageRules(t *testing.T) {
+	square, wide := pngOf(t, 256, 256), pngOf(t, 1500, 500)
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{imgmaxw, square, "256", nil},
+		{imgmaxw, square, "255", ErrImageWidth},
+		{imgmaxh, wide, "500", nil},
+		{imgmaxh, wide, "499", ErrImageHeight},
+		{imgminw, wide, "1200", nil},
+		{imgminw, square, "1200", ErrImageWidth},
+		{imgminh, square, "300", ErrImageHeight},
+		{imgratio, square, "1:1", nil},
+		{imgratio, wide, "3:1", nil},
+		{imgratio, wide, "16:9", ErrImageRatio},
+		{imgratio, bytes.NewReader(wide), "3:1", nil},
+		{imgmaxw, []byte("not an image"), "10", ErrImage},
+		{imgmaxw, square[:20], "10", ErrImage},
+		{imgmaxw, []byte{}, "10", nil},
+		{imgmaxw, nil, "10", nil},
+		{imgmaxw, (*bytes.Reader)(nil), "10", nil},
+		{imgratio, (*strings.Reader)(nil), "1:1", nil},
+		{imgmaxw, strings.NewReader("x"), "10", ErrImage},
+		{imgmaxw, io.MultiReader(bytes.NewReader(square)), "10", ErrUnsupported},
+		{imgmaxw, square, "wide", ErrBadParameter},
+		{imgratio, square, "1", ErrBadParameter},
+		{imgratio, square, "0:1", ErrBadParameter},
+		{imgmaxw, "image", "10", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+
+	// readers are rewound after their header is read
+	r := bytes.NewReader(wide)
+	resp, _ := New().Validate(ProfileImages{Avatar: wide, Banner: r})
+	if len(resp) != 1 || resp["Avatar"] != ErrImageWidth {
+		t.Fatalf("resp: %v", resp)
+	}
+	if r.Len() != len(wide) {
+		t.Fatalf("reader not rewound, %d bytes left", r.Len())
+	}
+	if resp, _ := New().Validate(ProfileImages{Avatar: square}); len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+}
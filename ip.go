@@ -0,0 +1,182 @@
+package govalidator
+
+import (
+	"errors"
+	"net"
+	"strings"
+)
+
+var (
+	ErrIP          = errors.New("invalid ip address")
+	ErrIPv4        = errors.New("invalid ipv4 address")
+	ErrIPv6        = errors.New("invalid ipv6 address")
+	ErrIPLoopback  = errors.New("loopback ip address not allowed")
+	ErrIPPrivate   = errors.New("private ip address not allowed")
+	ErrIPMulticast = errors.New("multicast ip address not allowed")
+
+	ErrCIDR         = errors.New("invalid cidr")
+	ErrCIDRv4       = errors.New("invalid ipv4 cidr")
+	ErrCIDRv6       = errors.New("invalid ipv6 cidr")
+	ErrCIDRHostBits = errors.New("cidr is not a network address")
+
+	ErrIPNotInCIDR = errors.New("ip address outside allowed networks")
+)
+
+const (
+	anyIP = iota
+	onlyIPv4
+	onlyIPv6
+)
+
+// parseIP parses s as an IP address of the given family. IPv4 addresses
+// are dotted decimals, IPv6 ones contain a colon, so that "::ffff:1.2.3.4"
+// is an IPv6 address even though it maps an IPv4 one.
+func parseIP(s string, family int) net.IP {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil
+	}
+	v6 := strings.Contains(s, ":")
+	if family == onlyIPv4 && v6 || family == onlyIPv6 && !v6 {
+		return nil
+	}
+	return ip
+}
+
+// checkIP tests whether a string is an IP address of the given family
+// and isn't in one of the ranges rejected by the parameters:
+// noloopback, noprivate (RFC 1918 and RFC 4193) and nomulticast.
+func checkIP(v interface{}, param string, family int, ruleErr error) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	ip := parseIP(s, family)
+	if ip == nil {
+		return ruleErr
+	}
+	for _, p := range SplitParams(param) {
+		switch p {
+		case "noloopback":
+			if ip.IsLoopback() {
+				return ErrIPLoopback
+			}
+		case "noprivate":
+			if ip.IsPrivate() {
+				return ErrIPPrivate
+			}
+		case "nomulticast":
+			if ip.IsMulticast() {
+				return ErrIPMulticast
+			}
+		default:
+			return ErrBadParameter
+		}
+	}
+	return nil
+}
+
+// isIP tests whether a string, or a net.IP, is an IPv4 or IPv6 address,
+// e.g. ip=noloopback,noprivate for public addresses only.
+func isIP(v interface{}, param string) error {
+	return checkIP(v, param, anyIP, ErrIP)
+}
+
+// isIPv4 tests whether a string is an IPv4 address in dotted decimal
+// notation. It takes the parameters of ip.
+func isIPv4(v interface{}, param string) error {
+	return checkIP(v, param, onlyIPv4, ErrIPv4)
+}
+
+// isIPv6 tests whether a string is an IPv6 address. It takes the
+// parameters of ip.
+func isIPv6(v interface{}, param string) error {
+	return checkIP(v, param, onlyIPv6, ErrIPv6)
+}
+
+// parseCIDR parses s as a network in CIDR notation of the given family,
+// returning the address as written along with the network.
+func parseCIDR(s string, family int) (net.IP, *net.IPNet) {
+	slash := strings.IndexByte(s, '/')
+	if slash < 0 || parseIP(s[:slash], family) == nil {
+		return nil, nil
+	}
+	ip, n, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, nil
+	}
+	return ip, n
+}
+
+// checkCIDR tests whether a string is a network in CIDR notation of the
+// given family. With the strict parameter the address must be the
+// network address, so "10.0.0.5/8" is rejected in favour of "10.0.0.0/8".
+func checkCIDR(v interface{}, param string, family int, ruleErr error) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	strict := false
+	switch param {
+	case "":
+	case "strict":
+		strict = true
+	default:
+		return ErrBadParameter
+	}
+	ip, n := parseCIDR(s, family)
+	if n == nil {
+		return ruleErr
+	}
+	if strict && !ip.Equal(n.IP) {
+		return ErrCIDRHostBits
+	}
+	return nil
+}
+
+// isCIDR tests whether a string, or a net.IPNet, is an IPv4 or IPv6
+// network in CIDR notation, e.g. "192.0.2.0/24".
+func isCIDR(v interface{}, param string) error {
+	return checkCIDR(v, param, anyIP, ErrCIDR)
+}
+
+// isCIDRv4 tests whether a string is an IPv4 network in CIDR notation.
+func isCIDRv4(v interface{}, param string) error {
+	return checkCIDR(v, param, onlyIPv4, ErrCIDRv4)
+}
+
+// isCIDRv6 tests whether a string is an IPv6 network in CIDR notation.
+func isCIDRv6(v interface{}, param string) error {
+	return checkCIDR(v, param, onlyIPv6, ErrCIDRv6)
+}
+
+// ipInCIDR tests whether a string is an IP address inside one of the
+// networks given as parameters, e.g. ip_in_cidr=10.0.0.0/8,192.168.0.0/16.
+func ipInCIDR(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	networks := SplitParams(param)
+	if len(networks) == 0 {
+		return ErrBadParameter
+	}
+	nets := make([]*net.IPNet, 0, len(networks))
+	for _, network := range networks {
+		_, n, err := net.ParseCIDR(network)
+		if err != nil {
+			return ErrBadParameter
+		}
+		nets = append(nets, n)
+	}
+	ip := parseIP(s, anyIP)
+	if ip == nil {
+		return ErrIP
+	}
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return nil
+		}
+	}
+	return ErrIPNotInCIDR
+}
@@ -0,0 +1,116 @@
+package govalidator
+
+import (
+	"net"
+	"testing"
+)
+
+func TestIP(t *testing.T) {
+	var nilStr *string
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{isIP, "192.0.2.1", "", nil},
+		{isIP, "2001:db8::1", "", nil},
+		{isIP, "", "", nil},
+		{isIP, nilStr, "", nil},
+		{isIP, "192.0.2", "", ErrIP},
+		{isIP, "192.0.2.01", "", ErrIP},
+		{isIP, "example.com", "", ErrIP},
+		{isIPv4, "192.0.2.1", "", nil},
+		{isIPv4, "::ffff:192.0.2.1", "", ErrIPv4},
+		{isIPv4, "2001:db8::1", "", ErrIPv4},
+		{isIPv6, "::ffff:192.0.2.1", "", nil},
+		{isIPv6, "192.0.2.1", "", ErrIPv6},
+		{isIP, "127.0.0.1", "noloopback", ErrIPLoopback},
+		{isIP, "::1", "noloopback", ErrIPLoopback},
+		{isIP, "10.1.2.3", "noloopback", nil},
+		{isIP, "10.1.2.3", "noloopback,noprivate", ErrIPPrivate},
+		{isIPv4, "172.16.0.1", "noprivate", ErrIPPrivate},
+		{isIPv6, "fd00::1", "noprivate", ErrIPPrivate},
+		{isIP, "224.0.0.1", "nomulticast", ErrIPMulticast},
+		{isIPv6, "ff02::1", "nomulticast", ErrIPMulticast},
+		{isIP, "8.8.8.8", "noloopback,noprivate,nomulticast", nil},
+		{isIP, "8.8.8.8", "public", ErrBadParameter},
+		{isIP, 1, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+type Peer struct {
+	Addr    net.IP `valid:"ipv4=noprivate"`
+	Gateway string `valid:"ip"`
+}
+
+func TestIPField(t *testing.T) {
+	resp, err := New().Validate(Peer{Addr: net.ParseIP("10.0.0.1"), Gateway: "gw"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp["Addr"] != ErrIPPrivate || resp["Gateway"] != ErrIP {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func TestCIDR(t *testing.T) {
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{isCIDR, "10.0.0.0/8", "", nil},
+		{isCIDR, "10.0.0.5/8", "", nil},
+		{isCIDR, "2001:db8::/32", "", nil},
+		{isCIDR, "", "", nil},
+		{isCIDR, "10.0.0.0", "", ErrCIDR},
+		{isCIDR, "10.0.0.0/33", "", ErrCIDR},
+		{isCIDR, "10.0.0.0/x", "", ErrCIDR},
+		{isCIDR, "10.0.0.0/8", "strict", nil},
+		{isCIDR, "10.0.0.5/8", "strict", ErrCIDRHostBits},
+		{isCIDR, "2001:db8::1/32", "strict", ErrCIDRHostBits},
+		{isCIDRv4, "192.168.0.0/16", "strict", nil},
+		{isCIDRv4, "2001:db8::/32", "", ErrCIDRv4},
+		{isCIDRv4, "::ffff:10.0.0.0/104", "", ErrCIDRv4},
+		{isCIDRv6, "::ffff:10.0.0.0/104", "", nil},
+		{isCIDRv6, "10.0.0.0/8", "", ErrCIDRv6},
+		{isCIDR, "10.0.0.0/8", "loose", ErrBadParameter},
+		{isCIDR, 8, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+func TestIPInCIDR(t *testing.T) {
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"10.1.2.3", "10.0.0.0/8,192.168.0.0/16", nil},
+		{"192.168.4.4", "10.0.0.0/8,192.168.0.0/16", nil},
+		{"172.16.0.1", "10.0.0.0/8,192.168.0.0/16", ErrIPNotInCIDR},
+		{"::ffff:10.0.0.1", "10.0.0.0/8", nil},
+		{"2001:db8::1", "2001:db8::/32", nil},
+		{"2001:db9::1", "2001:db8::/32", ErrIPNotInCIDR},
+		{"", "10.0.0.0/8", nil},
+		{"10.0.0", "10.0.0.0/8", ErrIP},
+		{"10.0.0.1", "10.0.0.0", ErrBadParameter},
+		{"10.0.0.1", "", ErrBadParameter},
+	}
+	for i, tt := range tests {
+		if err := ipInCIDR(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
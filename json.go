@@ -0,0 +1,73 @@
+package govalidator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+var (
+	ErrJSON       = errors.New("invalid json")
+	ErrJSONObject = errors.New("not a json object")
+	ErrJSONArray  = errors.New("not a json array")
+	ErrYAML       = errors.New("invalid yaml")
+	ErrTOML       = errors.New("invalid toml")
+)
+
+// jsonBytes returns the content of a string or of a []byte-like value
+// such as json.RawMessage. Empty values and nil pointers yield no bytes.
+func jsonBytes(v interface{}) ([]byte, error) {
+	st := reflect.ValueOf(v)
+	if st.Kind() == reflect.Ptr {
+		if st.IsNil() {
+			return nil, nil
+		}
+		st = st.Elem()
+	}
+	if st.Kind() == reflect.String {
+		return []byte(st.String()), nil
+	}
+	if st.Kind() != reflect.Slice || st.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, ErrUnsupported
+	}
+	return st.Bytes(), nil
+}
+
+// isJSON tests whether a string, json.RawMessage or []byte holds
+// syntactically valid JSON, such as a serialized feature-flag config.
+// Empty values are left to nonzero.
+func isJSON(v interface{}, param string) error {
+	b, err := jsonBytes(v)
+	if err != nil || len(b) == 0 {
+		return err
+	}
+	if !json.Valid(b) {
+		return ErrJSON
+	}
+	return nil
+}
+
+// isJSONObject tests whether the value holds a valid JSON object.
+func isJSONObject(v interface{}, param string) error {
+	return jsonShape(v, '{', ErrJSONObject)
+}
+
+// isJSONArray tests whether the value holds a valid JSON array.
+func isJSONArray(v interface{}, param string) error {
+	return jsonShape(v, '[', ErrJSONArray)
+}
+
+func jsonShape(v interface{}, open byte, shapeErr error) error {
+	b, err := jsonBytes(v)
+	if err != nil || len(b) == 0 {
+		return err
+	}
+	if !json.Valid(b) {
+		return ErrJSON
+	}
+	if b = bytes.TrimSpace(b); b[0] != open {
+		return shapeErr
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package govalidator
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type Webhook struct {
+	Payload json.RawMessage  `valid:"json;max=16"`
+	Headers json.RawMessage  `valid:"jsonobject"`
+	Events  *json.RawMessage `valid:"jsonarray"`
+}
+
+func TestJSONRawMessage(t *testing.T) {
+	v := newTestValidator()
+
+	events := json.RawMessage(` ["push"]`)
+	resp, _ := v.Validate(Webhook{Payload: json.RawMessage(`{"a":1}`), Headers: json.RawMessage(`{}`), Events: &events})
+	if len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	events = json.RawMessage(`{"push":true}`)
+	resp, _ = v.Validate(Webhook{Payload: json.RawMessage(`{"a":`), Headers: json.RawMessage(`[1]`), Events: &events})
+	if resp["Payload"] != ErrJSON || resp["Headers"] != ErrJSONObject || resp["Events"] != ErrJSONArray {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	resp, _ = v.Validate(Webhook{Payload: json.RawMessage(`{"key":"a long value"}`)})
+	if len(resp) != 1 || resp["Payload"] != ErrMax {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+type FlagConfig struct {
+	Template string  `valid:"json"`
+	Rules    string  `valid:"jsonarray"`
+	Defaults *string `valid:"jsonobject"`
+}
+
+func TestJSONString(t *testing.T) {
+	v := New()
+
+	defaults := `{"enabled": false}`
+	resp, _ := v.Validate(FlagConfig{Template: `"hello {{name}}"`, Rules: `[{"if": "beta"}]`, Defaults: &defaults})
+	if len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	defaults = `null`
+	resp, _ = v.Validate(FlagConfig{Template: `{"a": 1,}`, Rules: `{}`, Defaults: &defaults})
+	if resp["Template"] != ErrJSON || resp["Rules"] != ErrJSONArray || resp["Defaults"] != ErrJSONObject {
+		t.Fatalf("resp: %v", resp)
+	}
+}
@@ -0,0 +1,223 @@
+package govalidator
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var (
+	ErrJWT          = errors.New("invalid jwt")
+	ErrJWTSignature = errors.New("invalid jwt signature")
+	ErrJWTExpired   = errors.New("jwt expired or not yet valid")
+	ErrJWTClaim     = errors.New("missing or unexpected jwt claim")
+)
+
+// JWTKeys provides the keys verifying JWT signatures.
+type JWTKeys interface {
+	// JWTKey returns the key for the alg and kid of a token's header:
+	// a []byte secret for HS256, HS384 and HS512, an *rsa.PublicKey for
+	// RS* and PS*, an *ecdsa.PublicKey for ES* and an ed25519.PublicKey
+	// for EdDSA.
+	JWTKey(alg, kid string) (interface{}, error)
+}
+
+// JWTKeysFunc adapts a function to JWTKeys.
+type JWTKeysFunc func(alg, kid string) (interface{}, error)
+
+func (f JWTKeysFunc) JWTKey(alg, kid string) (interface{}, error) {
+	return f(alg, kid)
+}
+
+func SetJWTKeys(k JWTKeys) {
+	defaultValidator.SetJWTKeys(k)
+}
+
+// SetJWTKeys sets the keys jwt=verify checks signatures with. A nil k
+// removes them.
+func (d *Validator) SetJWTKeys(k JWTKeys) {
+	d.jwtKeys = k
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// decodeJWTPart decodes a base64url encoded JSON object of a token.
+func decodeJWTPart(part string, v interface{}) bool {
+	b, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil || len(b) == 0 || b[0] != '{' {
+		return false
+	}
+	return json.Unmarshal(b, v) == nil
+}
+
+// jwt tests whether a string is a JSON Web Token in compact form: a
+// base64url JSON header naming its alg, a base64url JSON object of
+// claims and a base64url signature. Tokens with "exp" or "nbf" claims
+// must be valid now. The parameters are:
+//
+//	verify      the signature must verify with a key of SetJWTKeys
+//	NAME        the claim NAME must be present, e.g. exp or iss
+//	NAME:VALUE  the claim NAME must be the string VALUE, or an array
+//	            holding it, e.g. iss:https://auth.example.com
+//
+// e.g. jwt=verify,exp,iss:https://auth.example.com,aud:webhooks.
+func (d *Validator) jwt(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	verify := false
+	var claims []string
+	for _, p := range SplitParams(param) {
+		switch {
+		case p == "verify":
+			if d.jwtKeys == nil {
+				return ErrBadParameter
+			}
+			verify = true
+		case p == "" || strings.HasPrefix(p, ":"):
+			return ErrBadParameter
+		default:
+			claims = append(claims, p)
+		}
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return ErrJWT
+	}
+	var header jwtHeader
+	var payload map[string]interface{}
+	if !decodeJWTPart(parts[0], &header) || header.Alg == "" || !decodeJWTPart(parts[1], &payload) {
+		return ErrJWT
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(sig) == 0 && header.Alg != "none" {
+		return ErrJWT
+	}
+
+	if verify {
+		key, err := d.jwtKeys.JWTKey(header.Alg, header.Kid)
+		if err != nil || !verifyJWT(header.Alg, key, parts[0]+"."+parts[1], sig) {
+			return ErrJWTSignature
+		}
+	}
+
+	now := timeNow()
+	if exp, ok := payload["exp"]; ok {
+		t, ok := exp.(float64)
+		if !ok || !now.Before(time.Unix(int64(t), 0)) {
+			return ErrJWTExpired
+		}
+	}
+	if nbf, ok := payload["nbf"]; ok {
+		t, ok := nbf.(float64)
+		if !ok || now.Before(time.Unix(int64(t), 0)) {
+			return ErrJWTExpired
+		}
+	}
+	for _, c := range claims {
+		name, want, hasValue := strings.Cut(c, ":")
+		got, ok := payload[name]
+		if !ok || hasValue && !claimHolds(got, want) {
+			return ErrJWTClaim
+		}
+	}
+	return nil
+}
+
+// claimHolds reports whether a claim is the string want, or an array of
+// strings holding it as "aud" may be.
+func claimHolds(claim interface{}, want string) bool {
+	switch c := claim.(type) {
+	case string:
+		return c == want
+	case []interface{}:
+		for _, v := range c {
+			if s, ok := v.(string); ok && s == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// verifyJWT verifies the signature of signed, the encoded header and
+// payload, with a key that must be of the type alg expects, so that a
+// public key can't be passed off as an HMAC secret.
+func verifyJWT(alg string, key interface{}, signed string, sig []byte) bool {
+	if alg == "EdDSA" {
+		k, ok := key.(ed25519.PublicKey)
+		return ok && len(k) == ed25519.PublicKeySize && ed25519.Verify(k, []byte(signed), sig)
+	}
+	if len(alg) != 5 {
+		return false
+	}
+	var hash crypto.Hash
+	switch alg[2:] {
+	case "256":
+		hash = crypto.SHA256
+	case "384":
+		hash = crypto.SHA384
+	case "512":
+		hash = crypto.SHA512
+	default:
+		return false
+	}
+	var digest []byte
+	switch hash {
+	case crypto.SHA256:
+		sum := sha256.Sum256([]byte(signed))
+		digest = sum[:]
+	case crypto.SHA384:
+		sum := sha512.Sum384([]byte(signed))
+		digest = sum[:]
+	case crypto.SHA512:
+		sum := sha512.Sum512([]byte(signed))
+		digest = sum[:]
+	}
+
+	switch alg[:2] {
+	case "HS":
+		k, ok := key.([]byte)
+		if !ok || len(k) == 0 {
+			return false
+		}
+		mac := hmac.New(hash.New, k)
+		mac.Write([]byte(signed))
+		return hmac.Equal(sig, mac.Sum(nil))
+	case "RS":
+		k, ok := key.(*rsa.PublicKey)
+		return ok && k != nil && rsa.VerifyPKCS1v15(k, hash, digest, sig) == nil
+	case "PS":
+		k, ok := key.(*rsa.PublicKey)
+		return ok && k != nil && rsa.VerifyPSS(k, hash, digest, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}) == nil
+	case "ES":
+		k, ok := key.(*ecdsa.PublicKey)
+		if !ok || k == nil {
+			return false
+		}
+		curves := map[crypto.Hash]elliptic.Curve{crypto.SHA256: elliptic.P256(), crypto.SHA384: elliptic.P384(), crypto.SHA512: elliptic.P521()}
+		size := (curves[hash].Params().BitSize + 7) / 8
+		if k.Curve != curves[hash] || len(sig) != 2*size {
+			return false
+		}
+		r, s := new(big.Int).SetBytes(sig[:size]), new(big.Int).SetBytes(sig[size:])
+		return ecdsa.Verify(k, digest, r, s)
+	}
+	return false
+}
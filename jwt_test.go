@@ -0,0 +1,142 @@
+package govalidator
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// signJWT builds a token of header and payload, signed by sign.
+func signJWT(header, payload string, sign func(signed string) []byte) string {
+	enc := base64.RawURLEncoding
+	signed := enc.EncodeToString([]byte(header)) + "." + enc.EncodeToString([]byte(payload))
+	return signed + "." + enc.EncodeToString(sign(signed))
+}
+
+func TestJWT(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	secret := []byte("webhook-secret")
+	hs256 := func(signed string) []byte {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(signed))
+		return mac.Sum(nil)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := New()
+	d.SetJWTKeys(JWTKeysFunc(func(alg, kid string) (interface{}, error) {
+		switch kid {
+		case "hmac":
+			return secret, nil
+		case "rsa":
+			return &rsaKey.PublicKey, nil
+		case "ec":
+			return &ecKey.PublicKey, nil
+		case "ed":
+			return edPub, nil
+		case "nil-rsa":
+			return (*rsa.PublicKey)(nil), nil
+		case "nil-ec":
+			return (*ecdsa.PublicKey)(nil), nil
+		}
+		return nil, errors.New("unknown key")
+	}))
+
+	claims := `{"iss":"https://auth.example.com","aud":["webhooks","api"],"exp":1714568400,"nbf":1714561200}`
+	valid := signJWT(`{"alg":"HS256","kid":"hmac"}`, claims, hs256)
+	rs256 := signJWT(`{"alg":"RS256","kid":"rsa"}`, claims, func(signed string) []byte {
+		sum := sha256.Sum256([]byte(signed))
+		sig, _ := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, sum[:])
+		return sig
+	})
+	ps256 := signJWT(`{"alg":"PS256","kid":"rsa"}`, claims, func(signed string) []byte {
+		sum := sha256.Sum256([]byte(signed))
+		sig, _ := rsa.SignPSS(rand.Reader, rsaKey, crypto.SHA256, sum[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		return sig
+	})
+	es256 := signJWT(`{"alg":"ES256","kid":"ec"}`, claims, func(signed string) []byte {
+		sum := sha256.Sum256([]byte(signed))
+		r, s, _ := ecdsa.Sign(rand.Reader, ecKey, sum[:])
+		sig := make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+		return sig
+	})
+	eddsa := signJWT(`{"alg":"EdDSA","kid":"ed"}`, claims, func(signed string) []byte {
+		return ed25519.Sign(edKey, []byte(signed))
+	})
+	// an HMAC signed with the public key as secret must not pass
+	confused := signJWT(`{"alg":"HS256","kid":"rsa"}`, claims, hs256)
+	forged := valid[:strings.LastIndex(valid, ".")+1] + base64.RawURLEncoding.EncodeToString(make([]byte, 32))
+	unsigned := signJWT(`{"alg":"none"}`, claims, func(string) []byte { return nil })
+	expired := signJWT(`{"alg":"HS256","kid":"hmac"}`, `{"exp":1714564800}`, hs256)
+	early := signJWT(`{"alg":"HS256","kid":"hmac"}`, `{"nbf":1714564801}`, hs256)
+
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{valid, "", nil},
+		{valid, "verify", nil},
+		{rs256, "verify", nil},
+		{ps256, "verify", nil},
+		{es256, "verify", nil},
+		{eddsa, "verify", nil},
+		{valid, "verify,exp,iss:https://auth.example.com,aud:webhooks", nil},
+		{valid, "sub", ErrJWTClaim},
+		{valid, "iss:https://evil.example.com", ErrJWTClaim},
+		{valid, "aud:admin", ErrJWTClaim},
+		{expired, "exp", ErrJWTExpired},
+		{early, "", ErrJWTExpired},
+		{confused, "", nil},
+		{confused, "verify", ErrJWTSignature},
+		{forged, "verify", ErrJWTSignature},
+		{unsigned, "", nil},
+		{unsigned, "verify", ErrJWTSignature},
+		{signJWT(`{"alg":"HS256","kid":"other"}`, claims, hs256), "verify", ErrJWTSignature},
+		{signJWT(`{"alg":"RS256","kid":"nil-rsa"}`, claims, hs256), "verify", ErrJWTSignature},
+		{signJWT(`{"alg":"PS256","kid":"nil-rsa"}`, claims, hs256), "verify", ErrJWTSignature},
+		{signJWT(`{"alg":"ES256","kid":"nil-ec"}`, claims, hs256), "verify", ErrJWTSignature},
+		{"a.b", "", ErrJWT},
+		{"a.b.c", "", ErrJWT},
+		{signJWT(`{"typ":"JWT"}`, claims, hs256), "", ErrJWT},
+		{signJWT(`{"alg":"HS256"}`, `[1]`, hs256), "", ErrJWT},
+		{signJWT(`{"alg":"HS256"}`, claims, func(string) []byte { return nil }), "", ErrJWT},
+		{"", "verify", nil},
+		{valid, ":x", ErrBadParameter},
+		{1, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := d.jwt(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+
+	if err := New().jwt(valid, "verify"); err != ErrBadParameter {
+		t.Fatalf("verify without keys: %v", err)
+	}
+}
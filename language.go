@@ -0,0 +1,157 @@
+package govalidator
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrBCP47              = errors.New("invalid language tag")
+	ErrLanguageNotAllowed = errors.New("language not allowed")
+)
+
+// grandfatheredTags are the tags of RFC 5646 that predate its syntax.
+var grandfatheredTags = map[string]bool{
+	"en-gb-oed": true, "i-ami": true, "i-bnn": true, "i-default": true, "i-enochian": true,
+	"i-hak": true, "i-klingon": true, "i-lux": true, "i-mingo": true, "i-navajo": true,
+	"i-pwn": true, "i-tao": true, "i-tay": true, "i-tsu": true, "sgn-be-fr": true,
+	"sgn-be-nl": true, "sgn-ch-de": true, "art-lojban": true, "cel-gaulish": true,
+	"no-bok": true, "no-nyn": true, "zh-guoyu": true, "zh-hakka": true, "zh-min": true,
+	"zh-min-nan": true, "zh-xiang": true,
+}
+
+// isLanguageTag reports whether s is well-formed according to RFC 5646,
+// e.g. "en", "en-US", "zh-Hant-TW" or "sr-Latn-RS-x-custom". Subtags
+// are checked for their form, not against the IANA registry.
+func isLanguageTag(s string) bool {
+	lower := strings.ToLower(s)
+	if grandfatheredTags[lower] {
+		return true
+	}
+	subtags := strings.Split(lower, "-")
+	for _, sub := range subtags {
+		if len(sub) == 0 || len(sub) > 8 {
+			return false
+		}
+		for i := 0; i < len(sub); i++ {
+			if !isAlnum(sub[i]) {
+				return false
+			}
+		}
+	}
+	// private use only, e.g. "x-whatever"
+	if subtags[0] == "x" {
+		return len(subtags) > 1
+	}
+
+	i := 0
+	// language, with up to three extended language subtags
+	switch lang := subtags[0]; {
+	case len(lang) >= 2 && len(lang) <= 3 && isAlpha(lang):
+		i++
+		for n := 0; n < 3 && i < len(subtags) && len(subtags[i]) == 3 && isAlpha(subtags[i]); n++ {
+			i++
+		}
+	case len(lang) >= 4 && isAlpha(lang):
+		i++
+	default:
+		return false
+	}
+	// script
+	if i < len(subtags) && len(subtags[i]) == 4 && isAlpha(subtags[i]) {
+		i++
+	}
+	// region
+	if i < len(subtags) && (len(subtags[i]) == 2 && isAlpha(subtags[i]) || len(subtags[i]) == 3 && isDigits(subtags[i])) {
+		i++
+	}
+	// variants
+	variants := map[string]bool{}
+	for ; i < len(subtags) && isVariant(subtags[i]); i++ {
+		if variants[subtags[i]] {
+			return false
+		}
+		variants[subtags[i]] = true
+	}
+	// extensions
+	singletons := map[string]bool{}
+	for i < len(subtags) && len(subtags[i]) == 1 && subtags[i] != "x" {
+		if singletons[subtags[i]] {
+			return false
+		}
+		singletons[subtags[i]] = true
+		i++
+		n := 0
+		for ; i < len(subtags) && len(subtags[i]) >= 2; i++ {
+			n++
+		}
+		if n == 0 {
+			return false
+		}
+	}
+	if i < len(subtags) {
+		return subtags[i] == "x" && i+1 < len(subtags)
+	}
+	return true
+}
+
+func isVariant(sub string) bool {
+	return len(sub) >= 5 || len(sub) == 4 && isASCIIDigit(rune(sub[0]))
+}
+
+func isAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isASCIILetter(rune(s[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isASCIIDigit(rune(s[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// bcp47 tests whether a string is a well-formed BCP 47 language tag.
+// The parameters, if any, are the supported languages: the tag must be
+// one of them or a more specific tag of one, so that bcp47=en,pt-BR
+// accepts "en-GB" but not "pt-PT". Tags compare case-insensitively.
+//
+// The check is syntactic only, against the RFC 5646 grammar, rather than
+// parsing with golang.org/x/text/language, which would add a dependency
+// to the package. Unlike language.Parse it accepts well-formed subtags
+// missing from the IANA registry, such as "xx-QQ", 4 to 8 letter primary
+// languages and up to three extended language subtags, e.g. "zh-yue-hak".
+// Extended languages and grandfathered or irregular tags such as
+// "i-klingon" are kept as written rather than mapped to their preferred
+// value, "tlh", so the allowed languages must be given as the input
+// writes them: "iw" isn't taken for "he", nor "zh-yue" for "yue".
+func bcp47(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	allowed := SplitParams(param)
+	for _, tag := range allowed {
+		if !isLanguageTag(tag) {
+			return ErrBadParameter
+		}
+	}
+	if !isLanguageTag(s) {
+		return ErrBCP47
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+	for _, tag := range allowed {
+		if strings.EqualFold(s, tag) || len(s) > len(tag) && s[len(tag)] == '-' && strings.EqualFold(s[:len(tag)], tag) {
+			return nil
+		}
+	}
+	return ErrLanguageNotAllowed
+}
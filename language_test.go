@@ -0,0 +1,47 @@
+package govalidator
+
+import "testing"
+
+func TestBCP47(t *testing.T) {
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"en", "", nil},
+		{"en-US", "", nil},
+		{"zh-Hant-TW", "", nil},
+		{"sr-Latn-RS", "", nil},
+		{"es-419", "", nil},
+		{"de-CH-1996", "", nil},
+		{"sl-rozaj-biske", "", nil},
+		{"zh-yue-HK", "", nil},
+		{"en-US-u-ca-gregory", "", nil},
+		{"en-a-bbb-x-a-ccc", "", nil},
+		{"x-whatever", "", nil},
+		{"i-klingon", "", nil},
+		{"", "", nil},
+		{"e", "", ErrBCP47},
+		{"en_US", "", ErrBCP47},
+		{"en-", "", ErrBCP47},
+		{"englishes-US", "", ErrBCP47},
+		{"de-419-DE", "", ErrBCP47},
+		{"a-DE", "", ErrBCP47},
+		{"ar-a-aaa-b-bbb-a-ccc", "", ErrBCP47},
+		{"de-DE-1901-1901", "", ErrBCP47},
+		{"en-u", "", ErrBCP47},
+		{"en-x", "", ErrBCP47},
+		{"en-GB", "en,pt-BR", nil},
+		{"EN", "en,pt-BR", nil},
+		{"pt-br", "en,pt-BR", nil},
+		{"pt-PT", "en,pt-BR", ErrLanguageNotAllowed},
+		{"eng", "en,pt-BR", ErrLanguageNotAllowed},
+		{"en", "en_US", ErrBadParameter},
+		{1, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := bcp47(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package govalidator
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SanitizeFunc returns the normalized form of s. param is the value
+// given after '=' in the mod tag, if any.
+type SanitizeFunc func(s string, param string) string
+
+func builtinSanitizers() map[string]SanitizeFunc {
+	return map[string]SanitizeFunc{
+		"trim":  func(s, _ string) string { return strings.TrimSpace(s) },
+		"ltrim": func(s, _ string) string { return strings.TrimLeftFunc(s, unicode.IsSpace) },
+		"rtrim": func(s, _ string) string { return strings.TrimRightFunc(s, unicode.IsSpace) },
+		"lower": func(s, _ string) string { return strings.ToLower(s) },
+		"upper": func(s, _ string) string { return strings.ToUpper(s) },
+		"squish": func(s, _ string) string {
+			return strings.Join(strings.Fields(s), " ")
+		},
+	}
+}
+
+func SetSanitizer(name string, fn SanitizeFunc) {
+	defaultValidator.SetSanitizer(name, fn)
+}
+
+func SetModTagName(tagName string) {
+	defaultValidator.SetModTagName(tagName)
+}
+
+// SetSanitizer registers a sanitizer usable in the mod tag. A nil fn
+// removes it.
+func (d *Validator) SetSanitizer(name string, fn SanitizeFunc) {
+	if name == "" {
+		return
+	}
+	if fn == nil {
+		delete(d.sanitizers, name)
+		return
+	}
+	d.sanitizers[name] = fn
+}
+
+// SetModTagName changes the name of the tag listing the sanitizers,
+// "mod" by default.
+func (d *Validator) SetModTagName(tagName string) {
+	if tagName != "" {
+		d.modTagName = tagName
+	}
+}
+
+// sanitizeField runs the comma separated sanitizers of the field's mod
+// tag, e.g. `mod:"trim,lower"`, in order on string and *string fields
+// that can be set. It runs before any rule so that a name of only
+// spaces doesn't pass nonzero.
+func (d *Validator) sanitizeField(field reflect.StructField, value reflect.Value) {
+	tag := field.Tag.Get(d.modTagName)
+	if tag == "" {
+		return
+	}
+	if value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.String || !value.CanSet() {
+		return
+	}
+	s := value.String()
+	for _, item := range SplitParams(tag) {
+		pair := strings.SplitN(item, "=", 2)
+		fn, ok := d.sanitizers[strings.TrimSpace(pair[0])]
+		if !ok {
+			continue
+		}
+		var param string
+		if len(pair) > 1 {
+			param = strings.TrimSpace(pair[1])
+		}
+		s = fn(s, param)
+	}
+	value.SetString(s)
+}
+
+// setDefault sets value to the default given as parameter when it is
+// the zero value and can be set, i.e. the struct was passed to Validate
+// by pointer. It runs before the rules that follow it in the tag.
+func setDefault(value reflect.Value, param string) error {
+	if !value.CanSet() || !value.IsZero() {
+		return nil
+	}
+	return setFromString(value, param)
+}
+
+// setFromString parses s into value according to its type, allocating
+// nil pointers.
+func setFromString(value reflect.Value, s string) error {
+	switch value.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(value.Type().Elem())
+		if err := setFromString(elem.Elem(), s); err != nil {
+			return err
+		}
+		value.Set(elem)
+	case reflect.String:
+		value.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return ErrBadParameter
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := asIntOf(value.Type(), s)
+		if err != nil || value.OverflowInt(i) {
+			return ErrBadParameter
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := asUint(s)
+		if err != nil || value.OverflowUint(u) {
+			return ErrBadParameter
+		}
+		value.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := asFloat(s)
+		if err != nil || value.OverflowFloat(f) {
+			return ErrBadParameter
+		}
+		value.SetFloat(f)
+	case reflect.Struct:
+		if value.Type() != timeType {
+			return ErrUnsupported
+		}
+		t, err := asTime(s)
+		if err != nil {
+			return err
+		}
+		value.Set(reflect.ValueOf(t))
+	default:
+		return ErrUnsupported
+	}
+	return nil
+}
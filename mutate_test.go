@@ -0,0 +1,70 @@
+package govalidator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Query struct {
+	Limit   int           `valid:"default=10;min=1;max=100"`
+	Sort    string        `valid:"default=asc;enum=asc,desc"`
+	Timeout time.Duration `valid:"default=5s"`
+	Page    *uint         `valid:"default=1"`
+	Strict  bool          `valid:"default=yes"`
+}
+
+func TestDefault(t *testing.T) {
+	v := New()
+
+	q := Query{Sort: "desc"}
+	resp, _ := v.Validate(&q)
+	if len(resp) != 1 || resp["Strict"] != ErrBadParameter {
+		t.Fatalf("resp: %v", resp)
+	}
+	if q.Limit != 10 || q.Sort != "desc" || q.Timeout != 5*time.Second || q.Page == nil || *q.Page != 1 {
+		t.Fatalf("query: %+v", q)
+	}
+
+	q = Query{Limit: 200}
+	resp, _ = v.Validate(&q)
+	if resp["Limit"] != ErrMax || q.Sort != "asc" {
+		t.Fatalf("resp: %v, query: %+v", resp, q)
+	}
+
+	// values passed by copy can't be set, the rules see the zero value
+	resp, _ = v.Validate(Query{})
+	if resp["Limit"] != ErrMin || !errors.Is(resp["Sort"], ErrEnum) {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+type Member struct {
+	Name   string  `mod:"squish" valid:"nonzero;max=10"`
+	Email  *string `mod:"trim,lower"`
+	Handle string  `mod:"trim,prefix=@"`
+}
+
+func TestSanitize(t *testing.T) {
+	v := New()
+	v.SetSanitizer("prefix", func(s, p string) string {
+		if strings.HasPrefix(s, p) {
+			return s
+		}
+		return p + s
+	})
+
+	email := "  Ice@Example.COM "
+	m := Member{Name: "  ice   pig  ", Email: &email, Handle: " ice "}
+	resp, _ := v.Validate(&m)
+	if len(resp) != 0 || m.Name != "ice pig" || email != "ice@example.com" || m.Handle != "@ice" {
+		t.Fatalf("resp: %v, member: %+v", resp, m)
+	}
+
+	m = Member{Name: "   "}
+	resp, _ = v.Validate(&m)
+	if resp["Name"] != ErrZeroValue {
+		t.Fatalf("resp: %v", resp)
+	}
+}
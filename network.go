@@ -0,0 +1,768 @@
+package govalidator
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrEmail   = errors.New("invalid email")
+	ErrEmailMX = errors.New("email domain has no mx record")
+	ErrURL     = errors.New("invalid url")
+	ErrURI     = errors.New("invalid uri")
+	ErrScheme  = errors.New("scheme not allowed")
+
+	ErrHostname = errors.New("invalid hostname")
+	ErrFQDN     = errors.New("invalid fully qualified domain name")
+	ErrDNSLabel = errors.New("invalid dns label")
+
+	ErrPort           = errors.New("invalid port")
+	ErrPrivilegedPort = errors.New("privileged port not allowed")
+	ErrHostPort       = errors.New("invalid host and port")
+
+	ErrUnresolvable = errors.New("host does not resolve")
+
+	ErrMagnet = errors.New("invalid magnet uri")
+	ErrMailto = errors.New("invalid mailto uri")
+	ErrTel    = errors.New("invalid tel uri")
+
+	ErrUnreachable = errors.New("url not reachable")
+)
+
+// Resolver performs the DNS lookups of the network rules. It is
+// satisfied by *net.Resolver.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+func SetResolver(r Resolver) {
+	defaultValidator.SetResolver(r)
+}
+
+// SetResolver replaces the resolver used by the network rules,
+// net.DefaultResolver by default, and clears their cache.
+func (d *Validator) SetResolver(r Resolver) {
+	if r == nil {
+		return
+	}
+	d.resolver = r
+	d.mxCache = newLookupCache(defaultLookupTTL, defaultLookupEntries)
+	d.hostCache = newLookupCache(defaultLookupTTL, defaultLookupEntries)
+}
+
+// HTTPClient sends the requests of url_reachable. It is satisfied by
+// *http.Client.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// maxRedirects bounds the redirects followed by the default HTTPClient.
+const maxRedirects = 5
+
+var errForbiddenAddress = errors.New("address not allowed")
+
+// publicHTTPClient is the default HTTPClient. It only connects to public
+// addresses, checked once DNS has resolved the host so that a hostname
+// can't point url_reachable at the loopback interface or the internal
+// network, and follows at most maxRedirects redirects, each of them
+// dialed with the same check. It ignores proxy settings, which would
+// otherwise be dialed in place of the host.
+var publicHTTPClient = &http.Client{
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 30 * time.Second,
+			Control: publicAddressOnly,
+		}).DialContext,
+		TLSHandshakeTimeout: 10 * time.Second,
+		MaxIdleConns:        100,
+		IdleConnTimeout:     90 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return errors.New("too many redirects")
+		}
+		if ip := net.ParseIP(req.URL.Hostname()); ip != nil && !isPublicIP(ip) {
+			return errForbiddenAddress
+		}
+		return nil
+	},
+}
+
+// publicAddressOnly is a net.Dialer Control function refusing to
+// connect to addresses that aren't public.
+func publicAddressOnly(network, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+		return errForbiddenAddress
+	}
+	return nil
+}
+
+// isPublicIP reports whether ip is neither loopback, private, link-local,
+// multicast nor unspecified.
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+func SetHTTPClient(c HTTPClient) {
+	defaultValidator.SetHTTPClient(c)
+}
+
+// SetHTTPClient replaces the client used by url_reachable and clears
+// its cache. The default client refuses to connect to loopback, private
+// and link-local addresses; set one that doesn't to reach them.
+func (d *Validator) SetHTTPClient(c HTTPClient) {
+	if c == nil {
+		return
+	}
+	d.httpClient = c
+	d.urlCache = newLookupCache(defaultLookupTTL, defaultLookupEntries)
+}
+
+func SetLookupTimeout(timeout time.Duration) {
+	defaultValidator.SetLookupTimeout(timeout)
+}
+
+// SetLookupTimeout bounds each DNS lookup of the network rules, 5s by
+// default. The context given to ValidateContext still applies.
+func (d *Validator) SetLookupTimeout(timeout time.Duration) {
+	d.lookupTimeout = timeout
+}
+
+func SetNetworkRules(enabled bool) {
+	defaultValidator.SetNetworkRules(enabled)
+}
+
+// SetNetworkRules turns on the rules that query the network for every
+// value they check, resolvable and url_reachable. They are off by default
+// and pass without doing anything until enabled, so that tests and
+// offline tools don't depend on the network. notpwned's default lookup is
+// off too, but fails closed, see SetPwnedFailOpen. email=mx asks for its
+// lookup explicitly and isn't affected.
+func (d *Validator) SetNetworkRules(enabled bool) {
+	d.network = enabled
+}
+
+// lookupContext returns ctx bounded by the lookup timeout.
+func (d *Validator) lookupContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d.lookupTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d.lookupTimeout)
+}
+
+// email checks that a string is a reasonable RFC 5322 address: a dot-atom
+// or quoted local part and a host name or address literal as domain.
+// Empty strings are left to nonzero. The parameters are:
+//
+//	dot  the domain must contain a dot, which rejects "user@localhost"
+//	mx   the domain must have an MX record, looked up through the
+//	     Validator's Resolver with the context given to ValidateContext
+func (d *Validator) email(ctx context.Context, v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	var requireDot, lookupMX bool
+	for _, p := range SplitParams(param) {
+		switch p {
+		case "dot":
+			requireDot = true
+		case "mx":
+			lookupMX = true
+		default:
+			return ErrBadParameter
+		}
+	}
+
+	domain, ok := splitEmail(s)
+	if !ok {
+		return ErrEmail
+	}
+	if requireDot && (strings.HasPrefix(domain, "[") || !strings.Contains(domain, ".")) {
+		return ErrEmail
+	}
+	if lookupMX && !strings.HasPrefix(domain, "[") {
+		return d.checkMX(ctx, domain)
+	}
+	return nil
+}
+
+// splitEmail validates the syntax of an address and returns its domain.
+func splitEmail(s string) (string, bool) {
+	at := strings.LastIndexByte(s, '@')
+	if at <= 0 || len(s) > 254 {
+		return "", false
+	}
+	local, domain := s[:at], s[at+1:]
+	if len(local) > 64 || !(isDotAtom(local) || isQuotedLocal(local)) {
+		return "", false
+	}
+	if strings.HasPrefix(domain, "[") && strings.HasSuffix(domain, "]") {
+		lit := strings.TrimPrefix(domain[1:len(domain)-1], "IPv6:")
+		return domain, net.ParseIP(lit) != nil
+	}
+	return domain, isHostname(strings.TrimSuffix(domain, "."))
+}
+
+func isDotAtom(s string) bool {
+	if s == "" || s[0] == '.' || s[len(s)-1] == '.' || strings.Contains(s, "..") {
+		return false
+	}
+	for _, r := range s {
+		if !isAtext(r) && r != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// isAtext reports whether r may appear unquoted in the local part.
+// Non-ASCII letters are accepted as RFC 6531 allows.
+func isAtext(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r > 127:
+		return true
+	}
+	return strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", r)
+}
+
+func isQuotedLocal(s string) bool {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return false
+	}
+	s = s[1 : len(s)-1]
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			if i++; i == len(s) {
+				return false
+			}
+		case c == '"' || c < 32 || c == 127:
+			return false
+		}
+	}
+	return true
+}
+
+// isHostname reports whether s is made of dot separated labels of
+// letters, digits and inner hyphens, each 1 to 63 characters long.
+func isHostname(s string) bool {
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if !isDNSLabel(label) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDNSLabel(label string) bool {
+	if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// hostname tests whether a string is an RFC 1123 host name: labels of
+// 1 to 63 letters, digits and inner hyphens, 253 characters at most,
+// with an optional trailing dot.
+func hostname(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if !isHostname(strings.TrimSuffix(s, ".")) {
+		return ErrHostname
+	}
+	return nil
+}
+
+// fqdn tests whether a string is a fully qualified domain name: a host
+// name with at least two labels whose last one is a plausible TLD, i.e.
+// letters only or an "xn--" IDN.
+func fqdn(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	s = strings.TrimSuffix(s, ".")
+	dot := strings.LastIndexByte(s, '.')
+	if dot < 0 || !isHostname(s) || !isTLD(s[dot+1:]) {
+		return ErrFQDN
+	}
+	return nil
+}
+
+func isTLD(label string) bool {
+	if strings.HasPrefix(strings.ToLower(label), "xn--") {
+		return len(label) > 4
+	}
+	if len(label) < 2 {
+		return false
+	}
+	for i := 0; i < len(label); i++ {
+		if c := label[i] | 0x20; c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// dnslabel tests whether a string is a single RFC 1123 label in lower
+// case, as required for Kubernetes resource names: at most 63 of a-z,
+// 0-9 and '-', starting and ending with an alphanumeric character.
+func dnslabel(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if !isDNSLabel(s) || strings.ToLower(s) != s {
+		return ErrDNSLabel
+	}
+	return nil
+}
+
+// checkMX looks up the MX records of domain. Found and not found answers
+// are cached, failed lookups are not and return their error.
+func (d *Validator) checkMX(ctx context.Context, domain string) error {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	if e, ok := d.mxCache.get(domain); ok {
+		return e.err
+	}
+	ctx, cancel := d.lookupContext(ctx)
+	defer cancel()
+	mxs, err := d.resolver.LookupMX(ctx, domain)
+	var dnsErr *net.DNSError
+	switch {
+	case err == nil && len(mxs) > 0:
+	case err == nil, errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+		err = ErrEmailMX
+	default:
+		return err
+	}
+	d.mxCache.set(domain, nil, err)
+	return err
+}
+
+// resolvable checks that a host name, or the host of a "host:port",
+// resolves to at least one address. IP addresses pass as they are. The
+// lookup goes through the Validator's Resolver, is cached like the MX
+// lookups of email and only happens once network rules are enabled with
+// SetNetworkRules.
+func (d *Validator) resolvable(ctx context.Context, v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if !d.network {
+		return nil
+	}
+	host := s
+	if h, _, err := net.SplitHostPort(s); err == nil {
+		host = h
+	}
+	if parseIP(host, anyIP) != nil {
+		return nil
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if !isHostname(host) {
+		return ErrHostname
+	}
+	if e, ok := d.hostCache.get(host); ok {
+		return e.err
+	}
+	ctx, cancel := d.lookupContext(ctx)
+	defer cancel()
+	addrs, err := d.resolver.LookupHost(ctx, host)
+	var dnsErr *net.DNSError
+	switch {
+	case err == nil && len(addrs) > 0:
+	case err == nil, errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+		err = ErrUnresolvable
+	default:
+		return err
+	}
+	d.hostCache.set(host, nil, err)
+	return err
+}
+
+// isURL checks that a string is an absolute URL with a host, such as
+// a webhook URL. The parameters, if any, are the allowed schemes, e.g.
+// url=https or url=https,http.
+func isURL(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || u.Hostname() == "" {
+		return ErrURL
+	}
+	return checkScheme(u, param)
+}
+
+// isURI checks that a string is an absolute URI, i.e. it has a scheme.
+// Unlike url it doesn't require a host, so "mailto:" or "urn:" URIs pass.
+// The parameters, if any, are the allowed schemes.
+func isURI(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" {
+		return ErrURI
+	}
+	return checkScheme(u, param)
+}
+
+// urlReachable checks that an http or https URL answers a HEAD
+// request, or a GET if HEAD isn't allowed, with a 2xx or 3xx status.
+// Requests go through the Validator's HTTPClient, are bounded by the
+// lookup timeout and only happen once network rules are enabled with
+// SetNetworkRules. Answers are cached, failed requests are not.
+func (d *Validator) urlReachable(ctx context.Context, v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if err := isURL(s, "http,https"); err != nil {
+		return err
+	}
+	if !d.network {
+		return nil
+	}
+	if e, ok := d.urlCache.get(s); ok {
+		return e.err
+	}
+	ctx, cancel := d.lookupContext(ctx)
+	defer cancel()
+	status, err := d.httpStatus(ctx, http.MethodHead, s)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = d.httpStatus(ctx, http.MethodGet, s)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return ErrUnreachable
+	}
+	if status < 200 || status >= 400 {
+		err = ErrUnreachable
+	}
+	d.urlCache.set(s, nil, err)
+	return err
+}
+
+func (d *Validator) httpStatus(ctx context.Context, method, rawURL string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	return resp.StatusCode, nil
+}
+
+// hasScheme reports whether s starts with scheme and a colon, in any case.
+func hasScheme(s, scheme string) bool {
+	return len(s) > len(scheme) && s[len(scheme)] == ':' && strings.EqualFold(s[:len(scheme)], scheme)
+}
+
+// magnet tests whether a string is a magnet link with at least one
+// exact topic, e.g. "magnet:?xt=urn:btih:<info hash>&dn=name". BitTorrent
+// info hashes must be 40 hex or 32 base32 characters.
+func magnet(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if !hasScheme(s, "magnet") || !strings.HasPrefix(s[len("magnet:"):], "?") {
+		return ErrMagnet
+	}
+	query, err := url.ParseQuery(s[len("magnet:?"):])
+	if err != nil {
+		return ErrMagnet
+	}
+	topics := 0
+	for key, values := range query {
+		if key != "xt" && !strings.HasPrefix(key, "xt.") {
+			continue
+		}
+		for _, xt := range values {
+			if !isMagnetTopic(xt) {
+				return ErrMagnet
+			}
+			topics++
+		}
+	}
+	if topics == 0 {
+		return ErrMagnet
+	}
+	return nil
+}
+
+func isMagnetTopic(xt string) bool {
+	parts := strings.SplitN(xt, ":", 3)
+	if len(parts) != 3 || !strings.EqualFold(parts[0], "urn") || parts[1] == "" || parts[2] == "" {
+		return false
+	}
+	if !strings.EqualFold(parts[1], "btih") {
+		return true
+	}
+	hash := parts[2]
+	switch len(hash) {
+	case 40:
+		for i := 0; i < len(hash); i++ {
+			if !isHexDigit(hash[i]) {
+				return false
+			}
+		}
+		return true
+	case 32:
+		for i := 0; i < len(hash); i++ {
+			if c := hash[i] | 0x20; !(c >= 'a' && c <= 'z' || hash[i] >= '2' && hash[i] <= '7') {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// mailto tests whether a string is an RFC 6068 mailto URI whose
+// addresses, from the path or the "to" header, are all valid emails,
+// e.g. "mailto:a@example.com,b@example.com?subject=Hi".
+func mailto(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if !hasScheme(s, "mailto") {
+		return ErrMailto
+	}
+	rest := s[len("mailto:"):]
+	var addrs []string
+	if q := strings.IndexByte(rest, '?'); q >= 0 {
+		query, err := url.ParseQuery(rest[q+1:])
+		if err != nil {
+			return ErrMailto
+		}
+		for _, to := range query["to"] {
+			addrs = append(addrs, strings.Split(to, ",")...)
+		}
+		rest = rest[:q]
+	}
+	if rest != "" {
+		to, err := url.PathUnescape(rest)
+		if err != nil {
+			return ErrMailto
+		}
+		addrs = append(addrs, strings.Split(to, ",")...)
+	}
+	if len(addrs) == 0 {
+		return ErrMailto
+	}
+	for _, addr := range addrs {
+		if _, ok := splitEmail(strings.TrimSpace(addr)); !ok {
+			return ErrMailto
+		}
+	}
+	return nil
+}
+
+// tel tests whether a string is an RFC 3966 tel URI: a global number
+// such as "tel:+1-201-555-0123;ext=42", or a local number with a
+// phone-context parameter such as "tel:7042;phone-context=example.com".
+func tel(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if !hasScheme(s, "tel") {
+		return ErrTel
+	}
+	parts := strings.Split(s[len("tel:"):], ";")
+	number, global := parts[0], strings.HasPrefix(parts[0], "+")
+	if global {
+		number = number[1:]
+	}
+	if !isPhoneDigits(number, !global) {
+		return ErrTel
+	}
+	hasContext := false
+	for _, p := range parts[1:] {
+		name, value := p, ""
+		if eq := strings.IndexByte(p, '='); eq >= 0 {
+			name, value = p[:eq], p[eq+1:]
+		}
+		if name == "" || strings.IndexFunc(name, func(r rune) bool {
+			return !(isASCIILetter(r) || isASCIIDigit(r) || r == '-')
+		}) >= 0 {
+			return ErrTel
+		}
+		switch strings.ToLower(name) {
+		case "ext":
+			if !isPhoneDigits(value, false) {
+				return ErrTel
+			}
+		case "phone-context":
+			if value == "" {
+				return ErrTel
+			}
+			hasContext = true
+		}
+	}
+	if !global && !hasContext {
+		return ErrTel
+	}
+	return nil
+}
+
+// isPhoneDigits reports whether s has at least one digit and otherwise
+// only the visual separators "-.()". Local numbers may also use '*',
+// '#' and hex digits.
+func isPhoneDigits(s string, local bool) bool {
+	digits := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case isASCIIDigit(rune(c)):
+			digits++
+		case c == '-' || c == '.' || c == '(' || c == ')':
+		case local && (c == '*' || c == '#' || isHexDigit(c)):
+			digits++
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
+
+func checkScheme(u *url.URL, param string) error {
+	schemes := SplitParams(param)
+	if len(schemes) == 0 {
+		return nil
+	}
+	for _, scheme := range schemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return nil
+		}
+	}
+	return ErrScheme
+}
+
+// isPort tests whether an integer, or a string of digits, is a TCP/UDP
+// port between 1 and 65535. With port=unprivileged the ports below 1024
+// are rejected as well.
+func isPort(v interface{}, param string) error {
+	st := reflect.ValueOf(v)
+	if st.Kind() == reflect.Ptr {
+		if st.IsNil() {
+			return nil
+		}
+		st = st.Elem()
+	}
+	var port int64
+	switch st.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		port = st.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if st.Uint() > 65535 {
+			return ErrPort
+		}
+		port = int64(st.Uint())
+	case reflect.String:
+		if st.Len() == 0 {
+			return nil
+		}
+		var ok bool
+		if port, ok = parsePort(st.String()); !ok {
+			return ErrPort
+		}
+	default:
+		return ErrUnsupported
+	}
+	return checkPort(port, param)
+}
+
+// parsePort parses a port written in decimal digits only.
+func parsePort(s string) (int64, bool) {
+	for i := 0; i < len(s); i++ {
+		if !isASCIIDigit(rune(s[i])) {
+			return 0, false
+		}
+	}
+	port, err := strconv.ParseInt(s, 10, 32)
+	return port, err == nil
+}
+
+func checkPort(port int64, param string) error {
+	switch param {
+	case "":
+	case "unprivileged":
+		if port > 0 && port < 1024 {
+			return ErrPrivilegedPort
+		}
+	default:
+		return ErrBadParameter
+	}
+	if port < 1 || port > 65535 {
+		return ErrPort
+	}
+	return nil
+}
+
+// hostport tests whether a string is a host and port as accepted by
+// net.SplitHostPort, e.g. "example.com:443" or "[2001:db8::1]:443". The
+// host must be a host name or an IP address and the port is checked as
+// by port, whose parameter it takes.
+func hostport(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	host, port, err := net.SplitHostPort(s)
+	if err != nil || host == "" {
+		return ErrHostPort
+	}
+	// only IPv6 literals are, and must be, in brackets
+	if strings.Contains(host, ":") != strings.HasPrefix(s, "[") {
+		return ErrHostPort
+	}
+	if parseIP(host, anyIP) == nil && !isHostname(strings.TrimSuffix(host, ".")) {
+		return ErrHostPort
+	}
+	p, ok := parsePort(port)
+	if !ok {
+		return ErrPort
+	}
+	return checkPort(p, param)
+}
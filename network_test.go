@@ -0,0 +1,432 @@
+package govalidator
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeResolver struct {
+	mx      map[string][]*net.MX
+	hosts   map[string][]string
+	lookups int
+}
+
+func (r *fakeResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	r.lookups++
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if mx, ok := r.mx[name]; ok {
+		return mx, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.lookups++
+	if host == "slow.example.com" {
+		<-ctx.Done()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestEmail(t *testing.T) {
+	v := New()
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"user@example.com", "", nil},
+		{"first.last+tag@sub.example.co.uk", "", nil},
+		{`"john doe"@example.com`, "", nil},
+		{"user@[192.168.0.1]", "", nil},
+		{"user@[IPv6:::1]", "", nil},
+		{"user@localhost", "", nil},
+		{"user@localhost", "dot", ErrEmail},
+		{"", "", nil},
+		{"plainaddress", "", ErrEmail},
+		{"@example.com", "", ErrEmail},
+		{"user@", "", ErrEmail},
+		{".user@example.com", "", ErrEmail},
+		{"us..er@example.com", "", ErrEmail},
+		{"user@-example.com", "", ErrEmail},
+		{"user@exa_mple.com", "", ErrEmail},
+		{"user name@example.com", "", ErrEmail},
+		{"user@example.com", "smtp", ErrBadParameter},
+		{42, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := v.email(context.Background(), tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+type Subscriber struct {
+	Email string `valid:"email=dot,mx"`
+}
+
+func TestEmailMX(t *testing.T) {
+	r := &fakeResolver{mx: map[string][]*net.MX{"example.com": {{Host: "mx.example.com."}}}}
+	v := New()
+	v.SetResolver(r)
+
+	for i := 0; i < 2; i++ {
+		resp, _ := v.Validate(Subscriber{Email: "user@Example.com"})
+		if len(resp) != 0 {
+			t.Fatalf("resp: %v", resp)
+		}
+		resp, _ = v.Validate(Subscriber{Email: "user@example.org"})
+		if resp["Email"] != ErrEmailMX {
+			t.Fatalf("resp: %v", resp)
+		}
+	}
+	if r.lookups != 2 {
+		t.Fatalf("expected cached lookups, got %d", r.lookups)
+	}
+
+	// failed lookups are reported but not cached
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp, _ := v.ValidateContext(ctx, Subscriber{Email: "user@example.net"})
+	if resp["Email"] != context.Canceled {
+		t.Fatalf("resp: %v", resp)
+	}
+	resp, _ = v.ValidateContext(context.Background(), Subscriber{Email: "user@example.net"})
+	if resp["Email"] != ErrEmailMX || r.lookups != 4 {
+		t.Fatalf("resp: %v, lookups: %d", resp, r.lookups)
+	}
+}
+
+func TestURL(t *testing.T) {
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{isURL, "https://example.com/hook?x=1", "", nil},
+		{isURL, "http://127.0.0.1:8080", "", nil},
+		{isURL, "HTTPS://example.com", "https", nil},
+		{isURL, "http://example.com", "https", ErrScheme},
+		{isURL, "ftp://example.com", "https, http", ErrScheme},
+		{isURL, "example.com/hook", "", ErrURL},
+		{isURL, "https://", "", ErrURL},
+		{isURL, "https://:80", "", ErrURL},
+		{isURL, "mailto:user@example.com", "", ErrURL},
+		{isURL, "http://exa mple.com", "", ErrURL},
+		{isURL, "", "", nil},
+		{isURI, "mailto:user@example.com", "", nil},
+		{isURI, "urn:isbn:0451450523", "urn", nil},
+		{isURI, "/relative/path", "", ErrURI},
+		{isURI, "mailto:user@example.com", "https", ErrScheme},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+func TestHostnames(t *testing.T) {
+	long := strings.Repeat("a", 63)
+	tests := []struct {
+		fn  ValidateFunc
+		v   interface{}
+		err error
+	}{
+		{hostname, "localhost", nil},
+		{hostname, "my-host.example.com.", nil},
+		{hostname, "1and1.com", nil},
+		{hostname, long + ".com", nil},
+		{hostname, long + "a.com", ErrHostname},
+		{hostname, strings.Repeat(long+".", 4) + "com", ErrHostname},
+		{hostname, "-host.com", ErrHostname},
+		{hostname, "host-.com", ErrHostname},
+		{hostname, "ho_st.com", ErrHostname},
+		{hostname, "host..com", ErrHostname},
+		{fqdn, "example.com", nil},
+		{fqdn, "sub.example.co.uk.", nil},
+		{fqdn, "example.xn--p1ai", nil},
+		{fqdn, "localhost", ErrFQDN},
+		{fqdn, "example.c", ErrFQDN},
+		{fqdn, "example.c0m", ErrFQDN},
+		{fqdn, "192.168.0.1", ErrFQDN},
+		{dnslabel, "my-service-1", nil},
+		{dnslabel, long, nil},
+		{dnslabel, long + "a", ErrDNSLabel},
+		{dnslabel, "My-Service", ErrDNSLabel},
+		{dnslabel, "my.service", ErrDNSLabel},
+		{dnslabel, "-service", ErrDNSLabel},
+		{dnslabel, "", nil},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, ""); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+func TestPort(t *testing.T) {
+	var nilPort *int
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{isPort, 80, "", nil},
+		{isPort, uint16(65535), "", nil},
+		{isPort, "8080", "", nil},
+		{isPort, "", "", nil},
+		{isPort, nilPort, "", nil},
+		{isPort, 0, "", ErrPort},
+		{isPort, 65536, "", ErrPort},
+		{isPort, uint32(70000), "", ErrPort},
+		{isPort, "-1", "", ErrPort},
+		{isPort, "+80", "", ErrPort},
+		{isPort, "http", "", ErrPort},
+		{isPort, 443, "unprivileged", ErrPrivilegedPort},
+		{isPort, "8443", "unprivileged", nil},
+		{isPort, 80, "high", ErrBadParameter},
+		{isPort, 8.0, "", ErrUnsupported},
+		{hostport, "example.com:443", "", nil},
+		{hostport, "127.0.0.1:8080", "", nil},
+		{hostport, "[2001:db8::1]:443", "", nil},
+		{hostport, "[::1]:22", "unprivileged", ErrPrivilegedPort},
+		{hostport, "2001:db8::1:443", "", ErrHostPort},
+		{hostport, "example.com", "", ErrHostPort},
+		{hostport, ":443", "", ErrHostPort},
+		{hostport, "[example.com]:443", "", ErrHostPort},
+		{hostport, "ex_ample.com:443", "", ErrHostPort},
+		{hostport, "example.com:0", "", ErrPort},
+		{hostport, "example.com:https", "", ErrPort},
+		{hostport, "example.com:99999", "", ErrPort},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+type Upstream struct {
+	Endpoint string `valid:"resolvable"`
+}
+
+func TestResolvable(t *testing.T) {
+	r := &fakeResolver{hosts: map[string][]string{"api.example.com": {"192.0.2.10"}}}
+	v := New()
+	v.SetResolver(r)
+
+	// off by default
+	if resp, _ := v.Validate(Upstream{Endpoint: "nowhere.example.com"}); len(resp) != 0 || r.lookups != 0 {
+		t.Fatalf("resp: %v, lookups: %d", resp, r.lookups)
+	}
+
+	v.SetNetworkRules(true)
+	tests := []struct {
+		v   string
+		err error
+	}{
+		{"api.example.com", nil},
+		{"API.example.com.:8443", nil},
+		{"nowhere.example.com", ErrUnresolvable},
+		{"nowhere.example.com:80", ErrUnresolvable},
+		{"192.0.2.1:80", nil},
+		{"[2001:db8::1]:443", nil},
+		{"bad_host", ErrHostname},
+	}
+	for i, tt := range tests {
+		resp, _ := v.Validate(Upstream{Endpoint: tt.v})
+		if resp["Endpoint"] != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, resp["Endpoint"])
+		}
+	}
+	if r.lookups != 2 {
+		t.Fatalf("expected cached lookups, got %d", r.lookups)
+	}
+
+	v.SetLookupTimeout(time.Millisecond)
+	resp, _ := v.Validate(Upstream{Endpoint: "slow.example.com"})
+	if resp["Endpoint"] != context.DeadlineExceeded {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func TestURISchemes(t *testing.T) {
+	hash := strings.Repeat("c12fe1c0", 5)
+	tests := []struct {
+		fn  ValidateFunc
+		v   interface{}
+		err error
+	}{
+		{magnet, "magnet:?xt=urn:btih:" + hash + "&dn=file.iso", nil},
+		{magnet, "MAGNET:?xt=urn:btih:" + strings.ToUpper(hash), nil},
+		{magnet, "magnet:?xt=urn:btih:MFRGGZDFMZTWQ2LKNNWG23TPOBYXE43U", nil},
+		{magnet, "magnet:?xt.1=urn:sha1:YNCKHTQCWBTRNJIV4WNAE52SJUQCZO5C&xt.2=urn:ed2k:31d6cfe0d16ae931b73c59d7e0c089c0", nil},
+		{magnet, "", nil},
+		{magnet, "magnet:?dn=file.iso", ErrMagnet},
+		{magnet, "magnet:xt=urn:btih:" + hash, ErrMagnet},
+		{magnet, "magnet:?xt=urn:btih:abc", ErrMagnet},
+		{magnet, "magnet:?xt=urn:btih:" + hash[:39] + "g", ErrMagnet},
+		{magnet, "magnet:?xt=btih:" + hash, ErrMagnet},
+		{magnet, "http://example.com/?xt=urn:btih:" + hash, ErrMagnet},
+		{mailto, "mailto:user@example.com", nil},
+		{mailto, "mailto:a@example.com,b@example.com?subject=Hi%20there", nil},
+		{mailto, "mailto:?to=user@example.com&body=hello", nil},
+		{mailto, "mailto:%22john%20doe%22@example.com", nil},
+		{mailto, "mailto:", ErrMailto},
+		{mailto, "mailto:?subject=Hi", ErrMailto},
+		{mailto, "mailto:user", ErrMailto},
+		{mailto, "mailto:user@example.com,nope", ErrMailto},
+		{mailto, "user@example.com", ErrMailto},
+		{tel, "tel:+1-201-555-0123", nil},
+		{tel, "tel:+1(201)555.0123;ext=42", nil},
+		{tel, "tel:7042;phone-context=example.com", nil},
+		{tel, "tel:*21#;phone-context=+1", nil},
+		{tel, "tel:7042", ErrTel},
+		{tel, "tel:+", ErrTel},
+		{tel, "tel:+1 201 555 0123", ErrTel},
+		{tel, "tel:+1-201-555-0123;ext=abc", ErrTel},
+		{tel, "tel:+1-201;=x", ErrTel},
+		{tel, "+1-201-555-0123", ErrTel},
+		{tel, 12015550123, ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, ""); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+type WebhookTarget struct {
+	URL string `valid:"url_reachable"`
+}
+
+func TestURLReachable(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/hook":
+		case "/get-only":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case "/moved":
+			http.Redirect(w, r, "/hook", http.StatusFound)
+		case "/slow":
+			<-r.Context().Done()
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	v := New()
+	v.SetHTTPClient(srv.Client())
+
+	// off by default, only the syntax is checked
+	if resp, _ := v.Validate(WebhookTarget{URL: srv.URL + "/missing"}); len(resp) != 0 || len(requests) != 0 {
+		t.Fatalf("resp: %v, requests: %v", resp, requests)
+	}
+	if resp, _ := v.Validate(WebhookTarget{URL: "ftp://example.com/hook"}); resp["URL"] != ErrScheme {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	v.SetNetworkRules(true)
+	tests := []struct {
+		path string
+		err  error
+	}{
+		{"/hook", nil},
+		{"/get-only", nil},
+		{"/moved", nil},
+		{"/missing", ErrUnreachable},
+		{"/hook", nil},
+	}
+	for i, tt := range tests {
+		resp, _ := v.Validate(WebhookTarget{URL: srv.URL + tt.path})
+		if resp["URL"] != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.path, tt.err, resp["URL"])
+		}
+	}
+	want := "HEAD /hook,HEAD /get-only,GET /get-only,HEAD /moved,HEAD /hook,HEAD /missing"
+	if got := strings.Join(requests, ","); got != want {
+		t.Fatalf("expected requests %s, got %s", want, got)
+	}
+
+	v.SetLookupTimeout(10 * time.Millisecond)
+	if resp, _ := v.Validate(WebhookTarget{URL: srv.URL + "/slow"}); resp["URL"] != context.DeadlineExceeded {
+		t.Fatalf("resp: %v", resp)
+	}
+	if resp, _ := v.Validate(WebhookTarget{URL: "http://127.0.0.1:1/hook"}); resp["URL"] != ErrUnreachable {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func TestPublicHTTPClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	// the default client won't reach the loopback test server
+	v := New()
+	v.SetNetworkRules(true)
+	if resp, _ := v.Validate(WebhookTarget{URL: srv.URL}); resp["URL"] != ErrUnreachable {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"fd00::1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"::ffff:127.0.0.1", false},
+	}
+	for _, tt := range tests {
+		if got := isPublicIP(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("isPublicIP(%s): expected %v, got %v", tt.ip, tt.want, got)
+		}
+	}
+	if err := publicAddressOnly("tcp", "169.254.169.254:80", nil); err != errForbiddenAddress {
+		t.Errorf("expected errForbiddenAddress, got %v", err)
+	}
+
+	redirect := func(rawURL string, via int) error {
+		req := httptest.NewRequest(http.MethodGet, rawURL, nil)
+		return publicHTTPClient.CheckRedirect(req, make([]*http.Request, via))
+	}
+	if err := redirect("http://example.com/", 1); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if err := redirect("http://example.com/", maxRedirects); err == nil {
+		t.Errorf("expected too many redirects")
+	}
+	if err := redirect("http://127.0.0.1/", 1); err != errForbiddenAddress {
+		t.Errorf("expected errForbiddenAddress, got %v", err)
+	}
+}
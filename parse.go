@@ -0,0 +1,201 @@
+package govalidator
+
+import (
+	"strings"
+)
+
+// maxAliasDepth bounds alias expansion so an alias referring to itself
+// can't loop forever.
+const maxAliasDepth = 8
+
+// directives are the tag words the validator handles itself rather than
+// through a ValidateFunc.
+var directives = map[string]bool{
+	"default":       true,
+	"dive":          true,
+	"keys":          true,
+	"endkeys":       true,
+	"structonly":    true,
+	"nostructlevel": true,
+}
+
+type rule struct {
+	name  string
+	param string
+}
+
+// ruleSet holds the rules of one level of a tag. The rules before a
+// "dive" apply to the field itself and those after it to each of its
+// elements, so "min=1;dive;nonzero;max=64" requires a non-empty slice of
+// non-empty strings of at most 64 characters. Every further dive goes
+// one level deeper, e.g. into the strings of a [][]string.
+//
+// For maps a "keys ... endkeys" block right after a dive holds the
+// rules of the keys, e.g. "dive;keys;regex=^[a-z]+$;endkeys;nonzero".
+// A block before any dive is ignored.
+//
+// For nested structs "structonly" runs the rules of the field itself,
+// such as required, without walking into the struct, and "nostructlevel"
+// walks into it but skips its Validatable hook.
+type ruleSet struct {
+	rules []rule
+	keys  []rule
+	dive  *ruleSet
+
+	structOnly    bool
+	noStructLevel bool
+}
+
+// parseTag splits a tag into its rules, expanding aliases in place, and
+// groups them by level.
+//
+// Rules are separated by ';' and a rule's name from its parameter by the
+// first '='. Parameters made of several values separate them with ','
+// and a value's key from its value with ':', e.g. "range=5,100" or
+// "password=min:12,upper:1". SplitParams and SplitKeyValue split
+// parameters that way for custom funcs. A literal ';', ',' or ':' is
+// escaped with a backslash, which has to be doubled inside a struct
+// tag: `valid:"contains=a\\;b"`.
+func (d *Validator) parseTag(tag string) *ruleSet {
+	rs := &ruleSet{}
+	level := rs
+	inKeys := false
+	for _, r := range d.parseRules(tag, 0) {
+		switch {
+		case r.name == "dive":
+			level.dive = &ruleSet{}
+			level = level.dive
+			inKeys = false
+		case r.name == "keys":
+			inKeys = true
+		case r.name == "endkeys":
+			inKeys = false
+		case r.name == "structonly":
+			level.structOnly = true
+		case r.name == "nostructlevel":
+			level.noStructLevel = true
+		case inKeys:
+			// a keys block before any dive has no map keys to
+			// apply to and is dropped
+			if level != rs {
+				level.keys = append(level.keys, r)
+			}
+		default:
+			level.rules = append(level.rules, r)
+		}
+	}
+	return rs
+}
+
+func (d *Validator) parseRules(tag string, depth int) []rule {
+	var rules []rule
+	for _, r := range splitUnescaped(tag, ';') {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		var name, param string
+		pair := strings.SplitN(r, "=", 2)
+		name = d.ruleName(strings.TrimSpace(pair[0]))
+		if len(pair) > 1 {
+			param = strings.TrimSpace(pair[1])
+		}
+		if alias, ok := d.aliases[name]; ok && param == "" && depth < maxAliasDepth {
+			rules = append(rules, d.parseRules(alias, depth+1)...)
+			continue
+		}
+		rules = append(rules, rule{name: name, param: param})
+	}
+	return rules
+}
+
+// ruleName returns the name a tag refers to. With SetIgnoreCase names
+// like "Min" or "NONZERO" resolve to the registered rule, directive or
+// alias that only differs in case. An exact match always wins, then
+// rules, context rules, field rules and aliases are searched in turn and
+// the first match in sort order is taken, so that names only differing
+// in case resolve the same way on every run.
+func (d *Validator) ruleName(name string) string {
+	if !d.ignoreCase || d.knownRule(name) {
+		return name
+	}
+	if lower := strings.ToLower(name); directives[lower] {
+		return lower
+	}
+	for _, known := range []string{
+		foldMatch(d.validateFuncs, name),
+		foldMatch(d.ctxFuncs, name),
+		foldMatch(d.fieldFuncs, name),
+		foldMatch(d.aliases, name),
+	} {
+		if known != "" {
+			return known
+		}
+	}
+	return name
+}
+
+// foldMatch returns the first key of m in sort order that equals name
+// under case folding, or "" if there is none.
+func foldMatch[V any](m map[string]V, name string) string {
+	match := ""
+	for known := range m {
+		if strings.EqualFold(known, name) && (match == "" || known < match) {
+			match = known
+		}
+	}
+	return match
+}
+
+// knownRule reports whether name is a rule, directive or alias.
+func (d *Validator) knownRule(name string) bool {
+	_, isFunc := d.validateFuncs[name]
+	_, isCtxFunc := d.ctxFuncs[name]
+	_, isFieldFunc := d.fieldFuncs[name]
+	_, isAlias := d.aliases[name]
+	return isFunc || isCtxFunc || isFieldFunc || isAlias || directives[name]
+}
+
+// SplitParams splits a rule parameter into its comma separated values,
+// trimming each of them. A backslash escapes a literal comma.
+func SplitParams(param string) []string {
+	if strings.TrimSpace(param) == "" {
+		return nil
+	}
+	items := splitUnescaped(param, ',')
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+	return items
+}
+
+// SplitKeyValue splits a parameter value like "min:12" around its first
+// unescaped colon. Without a colon the whole value is the key.
+func SplitKeyValue(item string) (key, value string) {
+	parts := splitUnescaped(item, ':')
+	key = strings.TrimSpace(parts[0])
+	if len(parts) > 1 {
+		value = strings.TrimSpace(strings.Join(parts[1:], ":"))
+	}
+	return key, value
+}
+
+// splitUnescaped splits s around the occurrences of sep that aren't
+// preceded by a backslash, and drops the backslash of those that are.
+func splitUnescaped(s string, sep byte) []string {
+	var parts []string
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == sep:
+			b.WriteByte(sep)
+			i++
+		case s[i] == sep:
+			parts = append(parts, b.String())
+			b.Reset()
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return append(parts, b.String())
+}
@@ -0,0 +1,77 @@
+package govalidator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	v := New()
+	tests := []struct {
+		tag   string
+		rules []rule
+	}{
+		{"nonzero; min=3 ;", []rule{{"nonzero", ""}, {"min", "3"}}},
+		{"regex=^a=b$;max=5", []rule{{"regex", "^a=b$"}, {"max", "5"}}},
+		{`contains=a\;b;len=3`, []rule{{"contains", "a;b"}, {"len", "3"}}},
+		{`regex=^\d+$`, []rule{{"regex", `^\d+$`}}},
+	}
+	for _, tt := range tests {
+		if rules := v.parseTag(tt.tag).rules; !reflect.DeepEqual(rules, tt.rules) {
+			t.Errorf("%q: expected %v, got %v", tt.tag, tt.rules, rules)
+		}
+	}
+}
+
+func TestSplitParams(t *testing.T) {
+	tests := []struct {
+		param string
+		items []string
+	}{
+		{"", nil},
+		{"a", []string{"a"}},
+		{" a , b,c ", []string{"a", "b", "c"}},
+		{`1\,000,2`, []string{"1,000", "2"}},
+		{"min:12,upper:1", []string{"min:12", "upper:1"}},
+	}
+	for _, tt := range tests {
+		if items := SplitParams(tt.param); !reflect.DeepEqual(items, tt.items) {
+			t.Errorf("%q: expected %q, got %q", tt.param, tt.items, items)
+		}
+	}
+
+	pairs := [][3]string{
+		{"min:12", "min", "12"},
+		{"between", "between", ""},
+		{" at : 10:30 ", "at", "10:30"},
+		{`a\:b:c`, "a:b", "c"},
+	}
+	for _, p := range pairs {
+		if key, value := SplitKeyValue(p[0]); key != p[1] || value != p[2] {
+			t.Errorf("%q: expected %q %q, got %q %q", p[0], p[1], p[2], key, value)
+		}
+	}
+}
+
+func TestParseTagDive(t *testing.T) {
+	rs := New().parseTag("min=1;dive;nonzero;dive;max=3")
+	if !reflect.DeepEqual(rs.rules, []rule{{"min", "1"}}) ||
+		!reflect.DeepEqual(rs.dive.rules, []rule{{"nonzero", ""}}) ||
+		!reflect.DeepEqual(rs.dive.dive.rules, []rule{{"max", "3"}}) || rs.dive.dive.dive != nil {
+		t.Fatalf("rules: %+v", rs)
+	}
+}
+
+func TestParseTagKeys(t *testing.T) {
+	rs := New().parseTag("max=3;dive;keys;min=2;regex=^[a-z]+$;endkeys;nonzero")
+	if !reflect.DeepEqual(rs.rules, []rule{{"max", "3"}}) ||
+		!reflect.DeepEqual(rs.dive.keys, []rule{{"min", "2"}, {"regex", "^[a-z]+$"}}) ||
+		!reflect.DeepEqual(rs.dive.rules, []rule{{"nonzero", ""}}) {
+		t.Fatalf("rules: %+v", rs)
+	}
+	// without a dive the keys block has nothing to apply to
+	rs = New().parseTag("max=3;keys;min=2;endkeys;nonzero")
+	if !reflect.DeepEqual(rs.rules, []rule{{"max", "3"}, {"nonzero", ""}}) || rs.keys != nil || rs.dive != nil {
+		t.Fatalf("rules: %+v", rs)
+	}
+}
@@ -0,0 +1,266 @@
+package govalidator
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrBcrypt   = errors.New("invalid bcrypt hash")
+	ErrArgon2id = errors.New("invalid argon2id hash")
+	ErrScrypt   = errors.New("invalid scrypt hash")
+	ErrPHC      = errors.New("invalid phc hash string")
+	ErrHashCost = errors.New("hash cost below minimum")
+)
+
+const bcryptAlphabet = "./ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// bcryptHash tests whether a string is a bcrypt hash, "$2b$" or one of
+// the older "$2a$" and "$2y$" prefixes, a cost of 04 to 31, and 22
+// characters of salt and 31 of hash. The optional parameter is the
+// minimum cost, e.g. bcrypt=10.
+func bcryptHash(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	minCost, err := hashCostParam(param)
+	if err != nil {
+		return err
+	}
+	if len(s) != 60 || s[0] != '$' || s[1] != '2' || !strings.ContainsRune("aby", rune(s[2])) || s[3] != '$' || s[6] != '$' {
+		return ErrBcrypt
+	}
+	cost, err := strconv.Atoi(s[4:6])
+	if err != nil || !isDigits(s[4:6]) || cost < 4 || cost > 31 {
+		return ErrBcrypt
+	}
+	for i := 7; i < len(s); i++ {
+		if strings.IndexByte(bcryptAlphabet, s[i]) < 0 {
+			return ErrBcrypt
+		}
+	}
+	if cost < minCost {
+		return ErrHashCost
+	}
+	return nil
+}
+
+func hashCostParam(param string) (int, error) {
+	if param == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(param)
+	if err != nil || n < 0 {
+		return 0, ErrBadParameter
+	}
+	return n, nil
+}
+
+// phcHash is a hash in the PHC string format,
+// $id[$v=version][$param=value(,param=value)*][$salt[$hash]].
+type phcHash struct {
+	id      string
+	version string
+	params  []string // name=value pairs, in order
+	salt    []byte
+	hash    []byte
+}
+
+// isPHCName reports whether s is a function or parameter name of the PHC
+// format, up to 32 lower case letters, digits and hyphens.
+func isPHCName(s string) bool {
+	if len(s) == 0 || len(s) > 32 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; !(c >= 'a' && c <= 'z' || isASCIIDigit(rune(c)) || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+func isPHCValue(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; !(isAlnum(c) || c == '/' || c == '+' || c == '.' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+func parsePHC(s string) (phcHash, bool) {
+	var h phcHash
+	fields := strings.Split(s, "$")
+	if len(fields) < 2 || fields[0] != "" || !isPHCName(fields[1]) {
+		return h, false
+	}
+	h.id, fields = fields[1], fields[2:]
+	if len(fields) > 0 && strings.HasPrefix(fields[0], "v=") {
+		if h.version = fields[0][2:]; h.version == "" || !isDigits(h.version) {
+			return h, false
+		}
+		fields = fields[1:]
+	}
+	if len(fields) > 0 && strings.Contains(fields[0], "=") {
+		for _, p := range strings.Split(fields[0], ",") {
+			name, value, ok := strings.Cut(p, "=")
+			if !ok || !isPHCName(name) || !isPHCValue(value) {
+				return h, false
+			}
+			h.params = append(h.params, p)
+		}
+		fields = fields[1:]
+	}
+	if len(fields) > 2 {
+		return h, false
+	}
+	var err error
+	if len(fields) > 0 {
+		if h.salt, err = base64.RawStdEncoding.Strict().DecodeString(fields[0]); err != nil || len(h.salt) == 0 {
+			return h, false
+		}
+	}
+	if len(fields) > 1 {
+		if h.hash, err = base64.RawStdEncoding.Strict().DecodeString(fields[1]); err != nil || len(h.hash) == 0 {
+			return h, false
+		}
+	}
+	return h, true
+}
+
+// intParams returns the integer parameters of h, which must be exactly
+// names, in this order.
+func (h phcHash) intParams(names ...string) (map[string]int64, bool) {
+	if len(h.params) != len(names) {
+		return nil, false
+	}
+	values := make(map[string]int64, len(names))
+	for i, p := range h.params {
+		name, value, _ := strings.Cut(p, "=")
+		n, err := strconv.ParseInt(value, 10, 64)
+		if name != names[i] || err != nil || !isDigits(value) {
+			return nil, false
+		}
+		values[name] = n
+	}
+	return values, true
+}
+
+// minCostParams parses parameters of the form name:N, the minimum value
+// of the hash parameter name, for the names given.
+func minCostParams(param string, names ...string) (map[string]int64, error) {
+	mins := map[string]int64{}
+	for _, p := range SplitParams(param) {
+		name, value, ok := strings.Cut(p, ":")
+		n, err := strconv.ParseInt(value, 10, 64)
+		if !ok || err != nil || n < 0 {
+			return nil, ErrBadParameter
+		}
+		known := false
+		for _, want := range names {
+			known = known || name == want
+		}
+		if !known {
+			return nil, ErrBadParameter
+		}
+		mins[name] = n
+	}
+	return mins, nil
+}
+
+// phc tests whether a string is a hash in the PHC string format, e.g.
+// "$pbkdf2-sha256$i=100000$c2FsdA$aGFzaA". The parameters, if any, are
+// the allowed function ids, e.g. phc=argon2id,scrypt.
+func phc(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	ids := SplitParams(param)
+	for _, id := range ids {
+		if !isPHCName(id) {
+			return ErrBadParameter
+		}
+	}
+	h, ok := parsePHC(s)
+	if !ok {
+		return ErrPHC
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	for _, id := range ids {
+		if h.id == id {
+			return nil
+		}
+	}
+	return ErrPHC
+}
+
+// argon2id tests whether a string is an argon2id hash in the PHC format
+// of the reference implementation, "$argon2id$v=19$m=65536,t=3,p=4$"
+// followed by the salt and hash. The parameters are the minimum memory in
+// KiB, iterations and parallelism, e.g. argon2id=m:65536,t:2.
+func argon2id(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	mins, err := minCostParams(param, "m", "t", "p")
+	if err != nil {
+		return err
+	}
+	h, ok := parsePHC(s)
+	if !ok || h.id != "argon2id" || h.version != "16" && h.version != "19" {
+		return ErrArgon2id
+	}
+	values, ok := h.intParams("m", "t", "p")
+	if !ok || values["p"] < 1 || values["p"] > 1<<24-1 || values["t"] < 1 ||
+		values["m"] < 8*values["p"] || values["m"] > 1<<32-1 ||
+		len(h.salt) < 8 || len(h.hash) < 4 {
+		return ErrArgon2id
+	}
+	for name, min := range mins {
+		if values[name] < min {
+			return ErrHashCost
+		}
+	}
+	return nil
+}
+
+// scryptHash tests whether a string is an scrypt hash in the PHC format,
+// "$scrypt$ln=15,r=8,p=1$" followed by the salt and hash, where ln is
+// the base 2 logarithm of the cost. The parameters are the minimum ln, r
+// and p, e.g. scrypt=ln:15.
+func scryptHash(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	mins, err := minCostParams(param, "ln", "r", "p")
+	if err != nil {
+		return err
+	}
+	h, ok := parsePHC(s)
+	if !ok || h.id != "scrypt" || h.version != "" {
+		return ErrScrypt
+	}
+	values, ok := h.intParams("ln", "r", "p")
+	if !ok || values["ln"] < 1 || values["ln"] > 63 || values["r"] < 1 || values["p"] < 1 ||
+		values["r"] >= 1<<30 || values["p"] >= (1<<30)/values["r"] || len(h.salt) == 0 || len(h.hash) == 0 {
+		return ErrScrypt
+	}
+	for name, min := range mins {
+		if values[name] < min {
+			return ErrHashCost
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package govalidator
+
+import "testing"
+
+func TestPasswordHashes(t *testing.T) {
+	const (
+		bcrypt12 = "$2b$12$R9h/cIPz0gi.URNNX3kh2OPST9/PgBkqquzi.Ss7KIUgO2t0jWMUW"
+		argon    = "$argon2id$v=19$m=65536,t=3,p=4$c29tZXNhbHQ$AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8"
+		scrypt15 = "$scrypt$ln=15,r=8,p=1$c2FsdHNhbHQxMjM0NTY3OA$z0D3Sx8429RGC1motvM3aLnLmihk9GHBhHl0Z3nCPkc"
+	)
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{bcryptHash, bcrypt12, "", nil},
+		{bcryptHash, bcrypt12, "10", nil},
+		{bcryptHash, bcrypt12, "13", ErrHashCost},
+		{bcryptHash, "$2a$04$" + bcrypt12[7:], "", nil},
+		{bcryptHash, "$2y$31$" + bcrypt12[7:], "", nil},
+		{bcryptHash, "$2x$12$" + bcrypt12[7:], "", ErrBcrypt},
+		{bcryptHash, "$2b$03$" + bcrypt12[7:], "", ErrBcrypt},
+		{bcryptHash, "$2b$32$" + bcrypt12[7:], "", ErrBcrypt},
+		{bcryptHash, "$2b$+9$" + bcrypt12[7:], "", ErrBcrypt},
+		{bcryptHash, bcrypt12[:59], "", ErrBcrypt},
+		{bcryptHash, bcrypt12[:59] + "+", "", ErrBcrypt},
+		{bcryptHash, "", "", nil},
+		{bcryptHash, bcrypt12, "high", ErrBadParameter},
+
+		{argon2id, argon, "", nil},
+		{argon2id, argon, "m:65536,t:3", nil},
+		{argon2id, argon, "m:131072", ErrHashCost},
+		{argon2id, argon, "t:4", ErrHashCost},
+		{argon2id, "$argon2id$v=19$m=16,t=3,p=4$c29tZXNhbHQ$AAECAwQ", "", ErrArgon2id},
+		{argon2id, "$argon2id$v=19$t=3,m=65536,p=4$c29tZXNhbHQ$AAECAwQ", "", ErrArgon2id},
+		{argon2id, "$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$AAECAwQ", "", ErrArgon2id},
+		{argon2id, "$argon2id$v=19$m=65536,t=3,p=4$c29tZXNhbHQ", "", ErrArgon2id},
+		{argon2id, "$argon2id$m=65536,t=3,p=4$c29tZXNhbHQ$AAECAwQ", "", ErrArgon2id},
+		{argon2id, "$argon2i$v=19$m=65536,t=3,p=4$c29tZXNhbHQ$AAECAwQ", "", ErrArgon2id},
+		{argon2id, "$argon2id$v=19$m=65536,t=3,p=4$c29tZXNhbHQ$AAECAwQ=", "", ErrArgon2id},
+		{argon2id, argon, "mem:1", ErrBadParameter},
+
+		{scryptHash, scrypt15, "", nil},
+		{scryptHash, scrypt15, "ln:15,r:8", nil},
+		{scryptHash, scrypt15, "ln:16", ErrHashCost},
+		{scryptHash, "$scrypt$ln=0,r=8,p=1$c2FsdA$aGFzaA", "", ErrScrypt},
+		{scryptHash, "$scrypt$ln=15,r=8$c2FsdA$aGFzaA", "", ErrScrypt},
+		{scryptHash, "$scrypt$ln=15,r=1024,p=1048576$c2FsdA$aGFzaA", "", ErrScrypt},
+		// r*p wraps around to 0 in an int64
+		{scryptHash, "$scrypt$ln=15,r=4294967296,p=4294967296$c2FsdA$aGFzaA", "", ErrScrypt},
+		{scryptHash, "$7$C6..../....SodiumChloride$kBGj9fHznVYFQMEn/qDCfrDevf9YDtcDdKvEqHJLV8D", "", ErrScrypt},
+
+		{phc, argon, "", nil},
+		{phc, scrypt15, "argon2id,scrypt", nil},
+		{phc, "$pbkdf2-sha256$i=100000$c2FsdA$aGFzaA", "", nil},
+		{phc, "$pbkdf2-sha256$i=100000$c2FsdA$aGFzaA", "argon2id", ErrPHC},
+		{phc, "$md5", "", nil},
+		{phc, "$MD5$c2FsdA", "", ErrPHC},
+		{phc, "$argon2id$v=19$m=65536$c2FsdA$aGFzaA$extra", "", ErrPHC},
+		{phc, "$argon2id$v=x$m=65536$c2FsdA$aGFzaA", "", ErrPHC},
+		{phc, "$argon2id$m=6=5$c2FsdA$aGFzaA", "", ErrPHC},
+		{phc, "argon2id$m=65536$c2FsdA$aGFzaA", "", ErrPHC},
+		{phc, bcrypt12, "", ErrPHC},
+		{phc, argon, "Argon2", ErrBadParameter},
+		{phc, 1, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
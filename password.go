@@ -0,0 +1,256 @@
+package govalidator
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrPasswordLength = errors.New("password length out of policy")
+	ErrPasswordUpper  = errors.New("password needs more upper case letters")
+	ErrPasswordLower  = errors.New("password needs more lower case letters")
+	ErrPasswordDigit  = errors.New("password needs more digits")
+	ErrPasswordSymbol = errors.New("password needs more symbols")
+	ErrEntropy        = errors.New("password too guessable")
+)
+
+// PasswordPolicy is the minimum composition of a password. Lengths count
+// characters, zero fields aren't checked.
+type PasswordPolicy struct {
+	MinLength int
+	MaxLength int
+	// Upper, Lower, Digit and Symbol are the minimum number of upper case
+	// letters, lower case letters, digits and other printable characters
+	// such as punctuation.
+	Upper  int
+	Lower  int
+	Digit  int
+	Symbol int
+}
+
+// ParsePasswordPolicy parses a policy written as the parameters of the
+// password rule, e.g. "min:12,upper:1,lower:1,digit:1,symbol:1".
+func ParsePasswordPolicy(s string) (PasswordPolicy, error) {
+	var p PasswordPolicy
+	for _, param := range SplitParams(s) {
+		key, value, ok := strings.Cut(param, ":")
+		if !ok {
+			return p, ErrBadParameter
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return p, ErrBadParameter
+		}
+		switch key {
+		case "min":
+			p.MinLength = n
+		case "max":
+			p.MaxLength = n
+		case "upper":
+			p.Upper = n
+		case "lower":
+			p.Lower = n
+		case "digit":
+			p.Digit = n
+		case "symbol":
+			p.Symbol = n
+		default:
+			return p, ErrBadParameter
+		}
+	}
+	return p, nil
+}
+
+// Check returns the first requirement of the policy that password fails.
+func (p PasswordPolicy) Check(password string) error {
+	n := utf8.RuneCountInString(password)
+	if n < p.MinLength || p.MaxLength > 0 && n > p.MaxLength {
+		return ErrPasswordLength
+	}
+	var upper, lower, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		case unicode.IsDigit(r):
+			digit++
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol++
+		}
+	}
+	switch {
+	case upper < p.Upper:
+		return ErrPasswordUpper
+	case lower < p.Lower:
+		return ErrPasswordLower
+	case digit < p.Digit:
+		return ErrPasswordDigit
+	case symbol < p.Symbol:
+		return ErrPasswordSymbol
+	}
+	return nil
+}
+
+func SetPasswordPolicy(name string, p PasswordPolicy) {
+	defaultValidator.SetPasswordPolicy(name, p)
+}
+
+// SetPasswordPolicy registers a policy that tags can reference as
+// password=@name, so that it's defined in one place. A zero p removes
+// the policy.
+func (d *Validator) SetPasswordPolicy(name string, p PasswordPolicy) {
+	if name == "" {
+		return
+	}
+	if p == (PasswordPolicy{}) {
+		delete(d.passwordPolicies, name)
+		return
+	}
+	if d.passwordPolicies == nil {
+		d.passwordPolicies = map[string]PasswordPolicy{}
+	}
+	d.passwordPolicies[name] = p
+}
+
+// password tests whether a string satisfies the policy given as
+// parameter, either inline, password=min:12,upper:1,digit:1, or by name,
+// password=@default, see SetPasswordPolicy.
+func (d *Validator) password(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	var p PasswordPolicy
+	if strings.HasPrefix(param, "@") {
+		if p, ok = d.passwordPolicies[param[1:]]; !ok {
+			return ErrBadParameter
+		}
+	} else if p, err = ParsePasswordPolicy(param); err != nil {
+		return err
+	}
+	return p.Check(s)
+}
+
+// commonPasswords are words and patterns attackers try first. Passwords
+// containing them gain little entropy from those characters.
+var commonPasswords = []string{
+	"password", "passw0rd", "qwerty", "qwertyuiop", "asdfgh", "zxcvbn",
+	"letmein", "welcome", "admin", "login", "master", "secret", "iloveyou",
+	"monkey", "dragon", "football", "baseball", "superman", "batman",
+	"sunshine", "princess", "shadow", "michael", "jordan", "trustno1",
+	"starwars", "whatever", "freedom", "hello", "charlie", "summer",
+	"winter", "spring", "autumn", "love", "god", "money", "access",
+	"changeme", "default", "guest", "test", "user", "root",
+}
+
+// leetReplacer undoes the usual character substitutions before the
+// password is looked up in commonPasswords.
+var leetReplacer = strings.NewReplacer("@", "a", "4", "a", "3", "e", "1", "i", "!", "i", "0", "o", "$", "s", "5", "s", "7", "t")
+
+// PasswordEntropy estimates the entropy of a password in bits. Each
+// character adds log2 of the size of the character classes the password
+// uses, 26 for lower case letters, 26 for upper case, 10 for digits, 33
+// for ASCII symbols and 100 for anything else. A word of commonPasswords,
+// found case-insensitively and after undoing leetReplacer substitutions,
+// adds log2(len(commonPasswords)) bits as a whole instead. A character
+// repeating the previous one, or continuing a run of consecutive
+// characters like "abc" or "321" from its third character on, adds one
+// bit.
+func PasswordEntropy(password string) float64 {
+	runes := []rune(password)
+	if len(runes) == 0 {
+		return 0
+	}
+	var pool float64
+	var lower, upper, digit, symbol, other bool
+	for _, r := range runes {
+		switch {
+		case r < utf8.RuneSelf && unicode.IsLower(r):
+			lower = true
+		case r < utf8.RuneSelf && unicode.IsUpper(r):
+			upper = true
+		case r < utf8.RuneSelf && unicode.IsDigit(r):
+			digit = true
+		case r < utf8.RuneSelf:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+	for _, c := range []struct {
+		used bool
+		size float64
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if c.used {
+			pool += c.size
+		}
+	}
+	charBits := math.Log2(pool)
+	wordBits := math.Log2(float64(len(commonPasswords)))
+
+	// lowering and the replacements map each rune to a single rune, so i
+	// indexes both runes and normalized
+	normalized := []rune(leetReplacer.Replace(strings.ToLower(password)))
+	var bits float64
+	for i := 0; i < len(runes); {
+		if n := commonPrefix(string(normalized[i:])); n > 0 {
+			bits += wordBits
+			i += n
+			continue
+		}
+		switch {
+		case i > 0 && runes[i] == runes[i-1]:
+			bits++
+		case i > 1 && runes[i]-runes[i-1] == runes[i-1]-runes[i-2] && abs(int(runes[i]-runes[i-1])) == 1:
+			bits++
+		default:
+			bits += charBits
+		}
+		i++
+	}
+	return bits
+}
+
+// commonPrefix returns the length of the longest common password s
+// starts with, 0 if none. The words are ASCII, so that it is their length
+// in runes as well as in bytes.
+func commonPrefix(s string) int {
+	n := 0
+	for _, word := range commonPasswords {
+		if len(word) > n && strings.HasPrefix(s, word) {
+			n = len(word)
+		}
+	}
+	return n
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// entropy tests whether a string has at least the number of bits of
+// entropy given as parameter, as estimated by PasswordEntropy, e.g.
+// entropy=60. It suits passphrases better than character class policies.
+func entropy(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	min, err := strconv.ParseFloat(param, 64)
+	if err != nil || min < 0 {
+		return ErrBadParameter
+	}
+	if PasswordEntropy(s) < min {
+		return ErrEntropy
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package govalidator
+
+import "testing"
+
+func TestPassword(t *testing.T) {
+	d := New()
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"Correct-horse-9", "min:12,upper:1,lower:1,digit:1,symbol:1", nil},
+		{"Short-9a", "min:12", ErrPasswordLength},
+		{"correct-horse-9", "min:12,upper:1", ErrPasswordUpper},
+		{"CORRECT-HORSE-9", "lower:1", ErrPasswordLower},
+		{"Correct-horse", "digit:1", ErrPasswordDigit},
+		{"Correcthorse99", "symbol:1", ErrPasswordSymbol},
+		{"Ünïcödé€€", "min:9,upper:1,symbol:2", nil},
+		{"toolongpassword", "max:8", ErrPasswordLength},
+		{"", "min:12", nil},
+		{(*string)(nil), "min:12", nil},
+		{"anything", "", nil},
+		{"x", "min", ErrBadParameter},
+		{"x", "min:-1", ErrBadParameter},
+		{"x", "length:3", ErrBadParameter},
+		{"x", "@missing", ErrBadParameter},
+		{12, "min:1", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := d.password(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+type Credentials struct {
+	Password string `valid:"password=@default"`
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	d := New()
+	p, err := ParsePasswordPolicy("min:12,upper:1,lower:1,digit:1,symbol:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (PasswordPolicy{MinLength: 12, Upper: 1, Lower: 1, Digit: 1, Symbol: 1}); p != want {
+		t.Fatalf("policy: %+v", p)
+	}
+	d.SetPasswordPolicy("default", p)
+	if resp, _ := d.Validate(Credentials{Password: "hunter2"}); resp["Password"] != ErrPasswordLength {
+		t.Fatalf("resp: %v", resp)
+	}
+	if resp, _ := d.Validate(Credentials{Password: "Hunter2!hunter2"}); len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	// tightening the policy applies to every field using it
+	p.MinLength = 16
+	d.SetPasswordPolicy("default", p)
+	if resp, _ := d.Validate(Credentials{Password: "Hunter2!hunter2"}); resp["Password"] != ErrPasswordLength {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	d.SetPasswordPolicy("default", PasswordPolicy{})
+	if err := d.password("x", "@default"); err != ErrBadParameter {
+		t.Fatalf("removed policy: %v", err)
+	}
+}
+
+func TestPasswordEntropy(t *testing.T) {
+	tests := []struct {
+		password string
+		min, max float64
+	}{
+		{"", 0, 0},
+		{"aaaaaaaaaaaaaaaa", 4, 20},
+		{"abcdefghijklmnop", 4, 25},
+		{"password123", 10, 20},
+		{"P@ssw0rd!", 10, 25},
+		{"Tr0ub4dor&3", 60, 80},
+		{"correct horse battery staple", 120, 200},
+		{"x7#Qm9!vL2", 60, 70},
+	}
+	for _, tt := range tests {
+		if bits := PasswordEntropy(tt.password); bits < tt.min || bits > tt.max {
+			t.Errorf("%q: %.1f bits, expected %v to %v", tt.password, bits, tt.min, tt.max)
+		}
+	}
+
+	for i, tt := range []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"correct horse battery staple", "60", nil},
+		{"Password1!", "60", ErrEntropy},
+		{"1111111111111111111111", "40", ErrEntropy},
+		{"", "60", nil},
+		{"x", "", ErrBadParameter},
+		{"x", "-1", ErrBadParameter},
+		{[]byte("x"), "60", ErrUnsupported},
+	} {
+		if err := entropy(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package govalidator
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrPEM         = errors.New("invalid pem data")
+	ErrX509Cert    = errors.New("invalid x509 certificate")
+	ErrCertExpired = errors.New("certificate expired or not yet valid")
+	ErrPEMKey      = errors.New("invalid pem key")
+)
+
+// pemBlocks decodes the PEM blocks of s, which must hold at least one
+// and nothing else but whitespace.
+func pemBlocks(s string) ([]*pem.Block, bool) {
+	var blocks []*pem.Block
+	rest := []byte(s)
+	for {
+		block, r := pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+		rest = r
+	}
+	if len(blocks) == 0 || strings.TrimSpace(string(rest)) != "" {
+		return nil, false
+	}
+	return blocks, true
+}
+
+// isPEM tests whether a string is PEM encoded data, one or more blocks.
+// The parameters, if any, are the block types allowed, e.g.
+// pem=CERTIFICATE,PUBLIC KEY.
+func isPEM(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	blocks, ok := pemBlocks(s)
+	if !ok {
+		return ErrPEM
+	}
+	params := SplitParams(param)
+	if len(params) == 0 {
+		return nil
+	}
+	types := make(map[string]bool, len(params))
+	for _, t := range params {
+		types[t] = true
+	}
+	for _, block := range blocks {
+		if !types[block.Type] {
+			return ErrPEM
+		}
+	}
+	return nil
+}
+
+// x509cert tests whether a string is a PEM encoded X.509 certificate, or
+// a chain of them. With the parameter valid every certificate must be
+// valid now, between its NotBefore and NotAfter. Signatures aren't
+// verified.
+func x509cert(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	checkValidity := false
+	switch param {
+	case "":
+	case "valid":
+		checkValidity = true
+	default:
+		return ErrBadParameter
+	}
+	blocks, ok := pemBlocks(s)
+	if !ok {
+		return ErrX509Cert
+	}
+	now := timeNow()
+	for _, block := range blocks {
+		if block.Type != "CERTIFICATE" {
+			return ErrX509Cert
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return ErrX509Cert
+		}
+		if checkValidity && (now.Before(cert.NotBefore) || now.After(cert.NotAfter)) {
+			return ErrCertExpired
+		}
+	}
+	return nil
+}
+
+// pemkey tests whether a string is a single PEM encoded key: a private
+// key in PKCS #8, PKCS #1 or SEC 1 form, or a public key in PKIX or
+// PKCS #1 form. The parameter private or public restricts it to one kind.
+// Encrypted keys aren't accepted.
+func pemkey(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if param != "" && param != "private" && param != "public" {
+		return ErrBadParameter
+	}
+	blocks, ok := pemBlocks(s)
+	if !ok || len(blocks) != 1 {
+		return ErrPEMKey
+	}
+	block := blocks[0]
+	var kind string
+	switch block.Type {
+	case "PRIVATE KEY":
+		kind = "private"
+		_, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		kind = "private"
+		_, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		kind = "private"
+		_, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		kind = "public"
+		_, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		kind = "public"
+		_, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return ErrPEMKey
+	}
+	if err != nil || param != "" && param != kind {
+		return ErrPEMKey
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package govalidator
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func pemString(typ string, der []byte) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}))
+}
+
+func TestPEM(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := func(notBefore, notAfter time.Time) string {
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "example.com"},
+			NotBefore:    notBefore,
+			NotAfter:     notAfter,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, edPub, edKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pemString("CERTIFICATE", der)
+	}
+	cert := certPEM(now.AddDate(0, -1, 0), now.AddDate(1, 0, 0))
+	expired := certPEM(now.AddDate(-2, 0, 0), now.AddDate(-1, 0, 0))
+	future := certPEM(now.AddDate(0, 0, 1), now.AddDate(1, 0, 0))
+
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(edKey)
+	spki, _ := x509.MarshalPKIXPublicKey(edPub)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	sec1, _ := x509.MarshalECPrivateKey(ecKey)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{isPEM, cert, "", nil},
+		{isPEM, cert + "\n" + pemString("PUBLIC KEY", spki), "", nil},
+		{isPEM, cert, "CERTIFICATE,PUBLIC KEY", nil},
+		{isPEM, pemString("PRIVATE KEY", pkcs8), "CERTIFICATE,PUBLIC KEY", ErrPEM},
+		{isPEM, "not pem", "", ErrPEM},
+		{isPEM, cert + "trailing", "", ErrPEM},
+		{isPEM, "", "", nil},
+		{x509cert, cert, "", nil},
+		{x509cert, cert, "valid", nil},
+		{x509cert, cert + expired, "", nil},
+		{x509cert, cert + expired, "valid", ErrCertExpired},
+		{x509cert, future, "valid", ErrCertExpired},
+		{x509cert, pemString("CERTIFICATE", []byte("garbage")), "", ErrX509Cert},
+		{x509cert, pemString("PUBLIC KEY", spki), "", ErrX509Cert},
+		{x509cert, strings.Replace(cert, "M", "", 1), "", ErrX509Cert},
+		{x509cert, cert, "fresh", ErrBadParameter},
+		{pemkey, pemString("PRIVATE KEY", pkcs8), "", nil},
+		{pemkey, pemString("PRIVATE KEY", pkcs8), "private", nil},
+		{pemkey, pemString("PRIVATE KEY", pkcs8), "public", ErrPEMKey},
+		{pemkey, pemString("EC PRIVATE KEY", sec1), "private", nil},
+		{pemkey, pemString("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)), "", nil},
+		{pemkey, pemString("RSA PUBLIC KEY", x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)), "public", nil},
+		{pemkey, pemString("PUBLIC KEY", spki), "public", nil},
+		{pemkey, pemString("PUBLIC KEY", spki) + pemString("PUBLIC KEY", spki), "", ErrPEMKey},
+		{pemkey, pemString("RSA PRIVATE KEY", sec1), "", ErrPEMKey},
+		{pemkey, pemString("ENCRYPTED PRIVATE KEY", pkcs8), "", ErrPEMKey},
+		{pemkey, cert, "", ErrPEMKey},
+		{pemkey, cert, "secret", ErrBadParameter},
+		{pemkey, 1, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+}
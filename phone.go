@@ -0,0 +1,186 @@
+package govalidator
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrE164  = errors.New("invalid e.164 phone number")
+	ErrPhone = errors.New("invalid phone number")
+)
+
+var e164Pattern = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+
+// PhoneFormat describes the phone numbers of a region.
+type PhoneFormat struct {
+	// CallingCode is the country calling code, e.g. "44".
+	CallingCode string
+	// TrunkPrefix is dialed before national numbers, e.g. "0", and
+	// dropped in international form.
+	TrunkPrefix string
+	// Pattern matches the national significant number, the digits
+	// following the calling code.
+	Pattern *regexp.Regexp
+}
+
+// PhoneProvider checks phone numbers with more complete data than the
+// built-in formats, typically an adapter for libphonenumber:
+//
+//	SetPhoneProvider(PhoneProviderFunc(func(number, region string) (bool, bool) {
+//		n, err := phonenumbers.Parse(number, region)
+//		return err == nil && phonenumbers.IsValidNumberForRegion(n, region), true
+//	}))
+type PhoneProvider interface {
+	// ValidPhone reports whether number is valid in region, an ISO
+	// 3166 alpha-2 code. known is false if the provider has no data
+	// for region, in which case the built-in formats are used.
+	ValidPhone(number, region string) (valid, known bool)
+}
+
+// PhoneProviderFunc adapts a function to a PhoneProvider.
+type PhoneProviderFunc func(number, region string) (valid, known bool)
+
+func (f PhoneProviderFunc) ValidPhone(number, region string) (bool, bool) {
+	return f(number, region)
+}
+
+func phoneFormat(pattern, code, trunk string) PhoneFormat {
+	return PhoneFormat{CallingCode: code, TrunkPrefix: trunk, Pattern: regexp.MustCompile(`^(` + pattern + `)$`)}
+}
+
+// builtinPhoneFormats returns the formats of the regions phone knows
+// out of the box. They check the length and leading digits of numbers,
+// not whether their ranges are assigned.
+func builtinPhoneFormats() map[string]PhoneFormat {
+	return map[string]PhoneFormat{
+		"US": phoneFormat(`[2-9]\d{2}[2-9]\d{6}`, "1", "1"),
+		"CA": phoneFormat(`[2-9]\d{2}[2-9]\d{6}`, "1", "1"),
+		"GB": phoneFormat(`[1-9]\d{8,9}`, "44", "0"),
+		"IE": phoneFormat(`[1-9]\d{6,9}`, "353", "0"),
+		"DE": phoneFormat(`[1-9]\d{6,13}`, "49", "0"),
+		"AT": phoneFormat(`[1-9]\d{3,12}`, "43", "0"),
+		"CH": phoneFormat(`[1-9]\d{8}`, "41", "0"),
+		"FR": phoneFormat(`[1-9]\d{8}`, "33", "0"),
+		"BE": phoneFormat(`[1-9]\d{7,8}`, "32", "0"),
+		"NL": phoneFormat(`[1-9]\d{8}`, "31", "0"),
+		"ES": phoneFormat(`[5-9]\d{8}`, "34", ""),
+		"PT": phoneFormat(`[29]\d{8}`, "351", ""),
+		"IT": phoneFormat(`0\d{5,10}|3\d{8,9}`, "39", ""),
+		"PL": phoneFormat(`[1-9]\d{8}`, "48", ""),
+		"SE": phoneFormat(`[1-9]\d{6,9}`, "46", "0"),
+		"NO": phoneFormat(`[2-9]\d{7}`, "47", ""),
+		"DK": phoneFormat(`[2-9]\d{7}`, "45", ""),
+		"FI": phoneFormat(`[1-9]\d{4,11}`, "358", "0"),
+		"AU": phoneFormat(`[2-478]\d{8}`, "61", "0"),
+		"NZ": phoneFormat(`[2-9]\d{7,9}`, "64", "0"),
+		"JP": phoneFormat(`[1-9]\d{8,9}`, "81", "0"),
+		"CN": phoneFormat(`1[3-9]\d{9}|[2-9]\d{8,10}`, "86", "0"),
+		"IN": phoneFormat(`[1-9]\d{9}`, "91", "0"),
+		"BR": phoneFormat(`[1-9]{2}9?\d{8}`, "55", "0"),
+		"MX": phoneFormat(`[1-9]\d{9}`, "52", ""),
+	}
+}
+
+func SetPhoneFormat(region string, f PhoneFormat) {
+	defaultValidator.SetPhoneFormat(region, f)
+}
+
+// SetPhoneFormat adds or replaces the format of a region, given as an
+// ISO 3166 alpha-2 code. A format without pattern removes the region.
+func (d *Validator) SetPhoneFormat(region string, f PhoneFormat) {
+	region = strings.ToUpper(region)
+	if f.Pattern == nil {
+		delete(d.phoneFormats, region)
+		return
+	}
+	d.phoneFormats[region] = f
+}
+
+func SetPhoneProvider(p PhoneProvider) {
+	defaultValidator.SetPhoneProvider(p)
+}
+
+// SetPhoneProvider sets a provider consulted before the built-in
+// formats. A nil p removes it.
+func (d *Validator) SetPhoneProvider(p PhoneProvider) {
+	d.phoneProvider = p
+}
+
+// e164 tests whether a string is a phone number in strict E.164 form:
+// '+', the country calling code and the subscriber number, 15 digits at
+// most and no separators.
+func e164(v interface{}, param string) error {
+	return checkString(v, e164Pattern.MatchString, ErrE164)
+}
+
+// phone tests whether a string is a phone number of one of the regions
+// given as parameters, e.g. phone=US,GB. Numbers may be written in
+// international form, "+44 20 7946 0958", or national form, "020 7946
+// 0958", with spaces, dots, hyphens and parentheses as separators.
+// Without parameters numbers must be in international form and match the
+// region of their calling code if it's known.
+func (d *Validator) phone(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	regions := SplitParams(param)
+	number := strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "").Replace(s)
+	if len(regions) == 0 {
+		if !e164Pattern.MatchString(number) {
+			return ErrPhone
+		}
+		known := false
+		for region, f := range d.phoneFormats {
+			if !strings.HasPrefix(number[1:], f.CallingCode) {
+				continue
+			}
+			known = true
+			if d.validPhone(number, region) {
+				return nil
+			}
+		}
+		if known {
+			return ErrPhone
+		}
+		return nil
+	}
+	for _, region := range regions {
+		region = strings.ToUpper(region)
+		_, hasFormat := d.phoneFormats[region]
+		if !hasFormat && d.phoneProvider == nil {
+			return ErrBadParameter
+		}
+		if d.validPhone(number, region) {
+			return nil
+		}
+	}
+	return ErrPhone
+}
+
+// validPhone checks number, stripped of its separators, in region.
+func (d *Validator) validPhone(number, region string) bool {
+	if d.phoneProvider != nil {
+		if valid, known := d.phoneProvider.ValidPhone(number, region); known {
+			return valid
+		}
+	}
+	f, ok := d.phoneFormats[region]
+	if !ok {
+		return false
+	}
+	var nsn string
+	switch {
+	case strings.HasPrefix(number, "+"+f.CallingCode):
+		nsn = number[1+len(f.CallingCode):]
+	case strings.HasPrefix(number, "+"):
+		return false
+	case f.TrunkPrefix != "" && strings.HasPrefix(number, f.TrunkPrefix):
+		nsn = number[len(f.TrunkPrefix):]
+	default:
+		nsn = number
+	}
+	return f.Pattern.MatchString(nsn)
+}
@@ -0,0 +1,93 @@
+package govalidator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestE164(t *testing.T) {
+	tests := []struct {
+		v   interface{}
+		err error
+	}{
+		{"+14155552671", nil},
+		{"+442079460958", nil},
+		{"", nil},
+		{"14155552671", ErrE164},
+		{"+1 415 555 2671", ErrE164},
+		{"+04155552671", ErrE164},
+		{"+1234567890123456", ErrE164},
+		{"+1", ErrE164},
+		{14155552671, ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := e164(tt.v, ""); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+func TestPhone(t *testing.T) {
+	v := New()
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"+1 (415) 555-2671", "US", nil},
+		{"415.555.2671", "US", nil},
+		{"1-415-555-2671", "US", nil},
+		{"020 7946 0958", "GB", nil},
+		{"+44 20 7946 0958", "US,GB", nil},
+		{"06 12 34 56 78", "FR", nil},
+		{"+33 6 12 34 56 78", "fr", nil},
+		{"", "US", nil},
+		{"+44 20 7946 0958", "US", ErrPhone},
+		{"415-555-267", "US", ErrPhone},
+		{"015-555-2671", "US", ErrPhone},
+		{"call me", "US", ErrPhone},
+		{"+1 415 555 2671", "", nil},
+		{"+44 20 7946 0958", "", nil},
+		{"+1 015 555 2671", "", ErrPhone},
+		{"+999 1234 5678", "", nil},
+		{"415 555 2671", "", ErrPhone},
+		{"+1 415 555 2671", "XX", ErrBadParameter},
+	}
+	for i, tt := range tests {
+		if err := v.phone(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+type ContactForm struct {
+	Phone string `valid:"phone=SG,US"`
+}
+
+func TestPhoneExtensions(t *testing.T) {
+	v := New()
+	v.SetPhoneFormat("SG", PhoneFormat{CallingCode: "65", Pattern: regexp.MustCompile(`^[689]\d{7}$`)})
+	if resp, _ := v.Validate(ContactForm{Phone: "+65 6123 4567"}); len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+	if resp, _ := v.Validate(ContactForm{Phone: "+65 1123 4567"}); resp["Phone"] != ErrPhone {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	// the provider takes precedence for the regions it knows
+	var calls []string
+	v.SetPhoneProvider(PhoneProviderFunc(func(number, region string) (bool, bool) {
+		calls = append(calls, region+" "+number)
+		return strings.HasSuffix(number, "4567"), region == "SG"
+	}))
+	if resp, _ := v.Validate(ContactForm{Phone: "+65 1123 4567"}); len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+	if resp, _ := v.Validate(ContactForm{Phone: "+1 415 555 2671"}); len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+	if strings.Join(calls, ",") != "SG +6511234567,SG +14155552671,US +14155552671" {
+		t.Fatalf("calls: %v", calls)
+	}
+}
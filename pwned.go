@@ -0,0 +1,162 @@
+package govalidator
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrPwned            = errors.New("password found in a data breach")
+	ErrPwnedUnavailable = errors.New("password breach check unavailable")
+)
+
+// pwnedRangeURL is the range API of Have I Been Pwned's Pwned Passwords.
+const pwnedRangeURL = "https://api.pwnedpasswords.com/range/"
+
+// PwnedPasswords looks up breached passwords by k-anonymity: only the
+// first 5 hex digits of the password's SHA-1 leave the process.
+type PwnedPasswords interface {
+	// Range returns the number of times each password whose upper case
+	// hex SHA-1 starts with prefix was seen in breaches, keyed by the
+	// remaining 35 hex digits.
+	Range(ctx context.Context, prefix string) (map[string]int, error)
+}
+
+// pwnedCacheEntries bounds the ranges notpwned caches. A range holds
+// around a thousand hashes, so it is kept well below defaultLookupEntries.
+const pwnedCacheEntries = 256
+
+// pwnedClient queries the Pwned Passwords range API.
+type pwnedClient struct {
+	client  HTTPClient
+	baseURL string
+}
+
+// NewPwnedPasswords returns a PwnedPasswords querying the Have I Been
+// Pwned range API through c, http.DefaultClient if nil.
+func NewPwnedPasswords(c HTTPClient) PwnedPasswords {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	return &pwnedClient{client: c, baseURL: pwnedRangeURL}
+}
+
+func (p *pwnedClient) Range(ctx context.Context, prefix string) (map[string]int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+prefix, nil)
+	if err != nil {
+		return nil, err
+	}
+	// padded responses hide the number of matches from observers
+	req.Header.Set("Add-Padding", "true")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("pwned passwords: status %d", resp.StatusCode)
+	}
+	counts := map[string]int{}
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		suffix, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil || n == 0 {
+			// padding entries have a count of 0
+			continue
+		}
+		counts[strings.ToUpper(suffix)] = n
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func SetPwnedPasswords(p PwnedPasswords) {
+	defaultValidator.SetPwnedPasswords(p)
+}
+
+// SetPwnedPasswords sets the breach lookup of notpwned. A nil p restores
+// the default, the Have I Been Pwned range API queried through the
+// Validator's HTTPClient.
+func (d *Validator) SetPwnedPasswords(p PwnedPasswords) {
+	d.pwned = p
+	d.pwnedCache = newLookupCache(defaultLookupTTL, pwnedCacheEntries)
+}
+
+func SetPwnedFailOpen(failOpen bool) {
+	defaultValidator.SetPwnedFailOpen(failOpen)
+}
+
+// SetPwnedFailOpen decides what notpwned does when the breach lookup
+// fails or times out: pass the password if failOpen, or fail with
+// ErrPwnedUnavailable, the default.
+func (d *Validator) SetPwnedFailOpen(failOpen bool) {
+	d.pwnedFailOpen = failOpen
+}
+
+// notpwned checks that a password wasn't seen in data breaches, or fewer
+// times than the parameter if given, e.g. notpwned=10. The default
+// lookup, the Have I Been Pwned API, is only queried once network rules
+// are enabled with SetNetworkRules; until then it is unavailable and
+// notpwned fails or passes as SetPwnedFailOpen decides. A lookup set with
+// SetPwnedPasswords is always queried. Answers are cached per hash prefix
+// and lookups are bounded by the lookup timeout.
+func (d *Validator) notpwned(ctx context.Context, v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	threshold := 1
+	if param != "" {
+		if threshold, err = strconv.Atoi(param); err != nil || threshold < 1 {
+			return ErrBadParameter
+		}
+	}
+	if d.pwned == nil && !d.network {
+		if d.pwnedFailOpen {
+			return nil
+		}
+		return ErrPwnedUnavailable
+	}
+	sum := sha1.Sum([]byte(s))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	var counts map[string]int
+	if e, ok := d.pwnedCache.get(prefix); ok {
+		counts = e.val.(map[string]int)
+	} else {
+		p := d.pwned
+		if p == nil {
+			p = &pwnedClient{client: d.httpClient, baseURL: pwnedRangeURL}
+		}
+		lookupCtx, cancel := d.lookupContext(ctx)
+		defer cancel()
+		if counts, err = p.Range(lookupCtx, prefix); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if d.pwnedFailOpen {
+				return nil
+			}
+			return ErrPwnedUnavailable
+		}
+		d.pwnedCache.set(prefix, counts, nil)
+	}
+	if counts[suffix] >= threshold {
+		return ErrPwned
+	}
+	return nil
+}
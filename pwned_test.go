@@ -0,0 +1,122 @@
+package govalidator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Registration struct {
+	Password string `valid:"notpwned"`
+}
+
+func TestNotPwned(t *testing.T) {
+	// SHA-1 of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		if r.Header.Get("Add-Padding") != "true" {
+			t.Errorf("missing padding header")
+		}
+		switch r.URL.Path {
+		case "/range/5BAA6":
+			fmt.Fprint(w, "003D68EB55068C33ACE09247EE4C639306B:3\r\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\r\n0123456789ABCDEF0123456789ABCDEF012:0\r\n")
+		case "/range/57E8A":
+			<-r.Context().Done()
+		default:
+			fmt.Fprint(w, "003D68EB55068C33ACE09247EE4C639306B:3\r\n")
+		}
+	}))
+	defer srv.Close()
+
+	// the default lookup is off with network rules, which fails closed
+	ctx := context.Background()
+	d := New()
+	if err := d.notpwned(ctx, "password", ""); err != ErrPwnedUnavailable {
+		t.Fatalf("network rules off: %v", err)
+	}
+	if resp, _ := d.ValidateContext(ctx, Registration{Password: "password"}); resp["Password"] != ErrPwnedUnavailable {
+		t.Fatalf("resp: %v", resp)
+	}
+	d.SetPwnedFailOpen(true)
+	if err := d.notpwned(ctx, "password", ""); err != nil {
+		t.Fatalf("network rules off, fail open: %v", err)
+	}
+
+	d = New()
+	d.SetPwnedPasswords(&pwnedClient{client: srv.Client(), baseURL: srv.URL + "/range/"})
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"password", "", ErrPwned},
+		{"password", "10000000", nil},
+		{"correct horse battery staple", "", nil},
+		{"", "", nil},
+		{"password", "0", ErrBadParameter},
+		{42, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := d.notpwned(ctx, tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+	// the range of "password" was fetched once
+	if got := strings.Join(requests, ","); strings.Count(got, "5BAA6") != 1 {
+		t.Fatalf("requests: %s", got)
+	}
+	if resp, _ := d.ValidateContext(ctx, Registration{Password: "password"}); resp["Password"] != ErrPwned {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	// SHA-1 of "slow" starts with 57E8A
+	d.SetLookupTimeout(10 * time.Millisecond)
+	if err := d.notpwned(ctx, "slow", ""); err != ErrPwnedUnavailable {
+		t.Fatalf("fail closed: %v", err)
+	}
+	d.SetPwnedFailOpen(true)
+	if err := d.notpwned(ctx, "slow", ""); err != nil {
+		t.Fatalf("fail open: %v", err)
+	}
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := d.notpwned(cancelled, "slow", ""); err != context.Canceled {
+		t.Fatalf("cancelled: %v", err)
+	}
+}
+
+func TestPwnedPasswords(t *testing.T) {
+	d := New()
+	var prefixes []string
+	d.SetPwnedPasswords(pwnedFunc(func(ctx context.Context, prefix string) (map[string]int, error) {
+		prefixes = append(prefixes, prefix)
+		if prefix == "5BAA6" {
+			return map[string]int{"1E4C9B93F3F0682250B6CF8331B7EE68FD8": 1}, nil
+		}
+		return nil, errors.New("down")
+	}))
+	if err := d.notpwned(context.Background(), "password", ""); err != ErrPwned {
+		t.Fatalf("err: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := d.notpwned(context.Background(), "hunter2", ""); err != ErrPwnedUnavailable {
+			t.Fatalf("err: %v", err)
+		}
+	}
+	// failures aren't cached
+	if len(prefixes) != 3 {
+		t.Fatalf("prefixes: %v", prefixes)
+	}
+}
+
+type pwnedFunc func(ctx context.Context, prefix string) (map[string]int, error)
+
+func (f pwnedFunc) Range(ctx context.Context, prefix string) (map[string]int, error) {
+	return f(ctx, prefix)
+}
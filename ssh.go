@@ -0,0 +1,166 @@
+package govalidator
+
+import (
+	"crypto/ecdh"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrSSHKey        = errors.New("invalid ssh public key")
+	ErrSSHKeyType    = errors.New("ssh key type not allowed")
+	ErrSSHKeyTooWeak = errors.New("ssh key too small")
+)
+
+// sshKeyKinds maps the key types of authorized_keys to the names
+// sshpubkey parameters use for them.
+var sshKeyKinds = map[string]string{
+	"ssh-ed25519":                        "ed25519",
+	"ssh-rsa":                            "rsa",
+	"ecdsa-sha2-nistp256":                "ecdsa",
+	"ecdsa-sha2-nistp384":                "ecdsa",
+	"ecdsa-sha2-nistp521":                "ecdsa",
+	"sk-ssh-ed25519@openssh.com":         "sk-ed25519",
+	"sk-ecdsa-sha2-nistp256@openssh.com": "sk-ecdsa",
+}
+
+var sshCurves = map[string]struct {
+	curve ecdh.Curve
+	bits  int
+}{
+	"nistp256": {ecdh.P256(), 256},
+	"nistp384": {ecdh.P384(), 384},
+	"nistp521": {ecdh.P521(), 521},
+}
+
+// sshString reads a length prefixed string of the SSH wire format.
+func sshString(b []byte) (s, rest []byte, ok bool) {
+	if len(b) < 4 {
+		return nil, nil, false
+	}
+	n := binary.BigEndian.Uint32(b)
+	if uint64(len(b)-4) < uint64(n) {
+		return nil, nil, false
+	}
+	return b[4 : 4+n], b[4+n:], true
+}
+
+// parseSSHKey checks the wire format blob of a key of type typ and
+// returns its size in bits.
+func parseSSHKey(typ string, blob []byte) (int, bool) {
+	name, rest, ok := sshString(blob)
+	if !ok || string(name) != typ {
+		return 0, false
+	}
+	var bits int
+	switch typ {
+	case "ssh-ed25519", "sk-ssh-ed25519@openssh.com":
+		var key []byte
+		if key, rest, ok = sshString(rest); !ok || len(key) != 32 {
+			return 0, false
+		}
+		bits = 256
+	case "ssh-rsa":
+		var e, n []byte
+		if e, rest, ok = sshString(rest); !ok || len(e) == 0 || e[0]&0x80 != 0 {
+			return 0, false
+		}
+		if n, rest, ok = sshString(rest); !ok || len(n) == 0 || n[0]&0x80 != 0 {
+			return 0, false
+		}
+		if exp := new(big.Int).SetBytes(e); exp.Bit(0) == 0 || exp.Cmp(big.NewInt(1)) <= 0 {
+			return 0, false
+		}
+		bits = new(big.Int).SetBytes(n).BitLen()
+	default:
+		// ecdsa-sha2-* and sk-ecdsa-sha2-*
+		var curveName, point []byte
+		if curveName, rest, ok = sshString(rest); !ok || !strings.Contains(typ, "-"+string(curveName)) {
+			return 0, false
+		}
+		c, ok := sshCurves[string(curveName)]
+		if !ok {
+			return 0, false
+		}
+		if point, rest, ok = sshString(rest); !ok {
+			return 0, false
+		}
+		if _, err := c.curve.NewPublicKey(point); err != nil {
+			return 0, false
+		}
+		bits = c.bits
+	}
+	if strings.HasPrefix(typ, "sk-") {
+		// the application of security keys, usually "ssh:"
+		if _, rest, ok = sshString(rest); !ok {
+			return 0, false
+		}
+	}
+	return bits, len(rest) == 0
+}
+
+// sshpubkey tests whether a string is an SSH public key as written in
+// authorized_keys: the key type, the base64 key and an optional comment,
+// e.g. "ssh-ed25519 AAAAC3Nza... deploy@ci". Options before the key type
+// aren't accepted. The parameters, if any, are the kinds of keys allowed,
+// ed25519, rsa, ecdsa, sk-ed25519 and sk-ecdsa, each with an optional
+// minimum size in bits, e.g. sshpubkey=ed25519,rsa:3072.
+func sshpubkey(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	var allowed map[string]int
+	for _, p := range SplitParams(param) {
+		kind, size, hasSize := strings.Cut(p, ":")
+		minBits := 0
+		if hasSize {
+			if minBits, err = strconv.Atoi(size); err != nil || minBits <= 0 {
+				return ErrBadParameter
+			}
+		}
+		known := false
+		for _, k := range sshKeyKinds {
+			known = known || k == kind
+		}
+		if !known {
+			return ErrBadParameter
+		}
+		if allowed == nil {
+			allowed = map[string]int{}
+		}
+		allowed[kind] = minBits
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) < 2 || strings.ContainsAny(s, "\r\n") {
+		return ErrSSHKey
+	}
+	kind, ok := sshKeyKinds[fields[0]]
+	if !ok {
+		return ErrSSHKey
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return ErrSSHKey
+	}
+	bits, ok := parseSSHKey(fields[0], blob)
+	if !ok {
+		return ErrSSHKey
+	}
+	if allowed == nil {
+		return nil
+	}
+	minBits, ok := allowed[kind]
+	if !ok {
+		return ErrSSHKeyType
+	}
+	if bits < minBits {
+		return ErrSSHKeyTooWeak
+	}
+	return nil
+}
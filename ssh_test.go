@@ -0,0 +1,65 @@
+package govalidator
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+)
+
+const (
+	sshEd25519  = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIC7/bHAQwcoaZnlSVDVm8XhOyNWPaQsOUP2Vh8FNGBkd deploy@ci"
+	sshRSA2048  = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDCAQDPKhR6r7W7gdS9k/fJP7ATZt4lvuWZ6Z5C2XIZY5kUEjF/tRdOfX1vpt1qN6mUrNSPbm0v0H2Aq0OIraYqK3VwWO4E56nEOd83g2LIpuhmGva0t4FIN/lU9aXX27nzK1v8nm5VqE8or65741VpCDDuN/gtMmYrD/zUUjWPVL/ClTOe95xEpDeBpWA57rEm/X3wo/aYGiQNSXdZzw2w2qSmD0sFuUL4JFN+U+CKDVo6cMmieQNoQmCFlLSmMGDY38BEzdvTGyYO6aoL2wJJkesFkysGBEMR1trA2CHqzvpx5tzosA6RK5JQ+/o4YM3d3o4k4Oz2QW+5u7bp+Z7n"
+	sshECDSA384 = "ecdsa-sha2-nistp384 AAAAE2VjZHNhLXNoYTItbmlzdHAzODQAAAAIbmlzdHAzODQAAABhBBMeW7OgAG5190UWCvgBoNcYJXMAZpD+N4LJ9fSjzEa1IrIy9vbSZEpFK/kKzp0zKuk9v3FISCzkwb7Mzz0eQUo0449tw2KPsR3qujdTe4M7RP03+GR3uTCFsjgcUCaIAQ== root@vm"
+)
+
+// sshKey builds an authorized_keys line of typ from wire format strings.
+func sshKey(typ string, fields ...string) string {
+	var blob []byte
+	for _, f := range append([]string{typ}, fields...) {
+		blob = binary.BigEndian.AppendUint32(blob, uint32(len(f)))
+		blob = append(blob, f...)
+	}
+	return typ + " " + base64.StdEncoding.EncodeToString(blob)
+}
+
+func TestSSHPubKey(t *testing.T) {
+	key32 := string(make([]byte, 32))
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{sshEd25519, "", nil},
+		{sshRSA2048, "", nil},
+		{sshECDSA384, "", nil},
+		{sshEd25519, "ed25519,rsa:3072", nil},
+		{sshRSA2048, "ed25519,rsa:3072", ErrSSHKeyTooWeak},
+		{sshRSA2048, "rsa:2048", nil},
+		{sshECDSA384, "ed25519,rsa", ErrSSHKeyType},
+		{sshECDSA384, "ecdsa:384", nil},
+		{sshKey("sk-ssh-ed25519@openssh.com", key32, "ssh:"), "sk-ed25519", nil},
+		{sshKey("sk-ssh-ed25519@openssh.com", key32), "", ErrSSHKey},
+		{sshKey("ssh-ed25519", key32[:31]), "", ErrSSHKey},
+		{sshKey("ssh-ed25519", key32, "extra"), "", ErrSSHKey},
+		{sshKey("ssh-rsa", "\x01\x00\x01", "\x80"), "", ErrSSHKey},
+		{sshKey("ssh-rsa", "\x02", "\x7f\xff"), "", ErrSSHKey},
+		{sshKey("ecdsa-sha2-nistp256", "nistp384", "\x04"), "", ErrSSHKey},
+		{sshKey("ecdsa-sha2-nistp256", "nistp256", "\x04"+key32+key32), "", ErrSSHKey},
+		{sshKey("ssh-dss", "p", "q", "g", "y"), "", ErrSSHKey},
+		// the type of the line must match the blob
+		{"ssh-rsa " + sshEd25519[len("ssh-ed25519 "):], "", ErrSSHKey},
+		{"ssh-ed25519", "", ErrSSHKey},
+		{"ssh-ed25519 not-base64!", "", ErrSSHKey},
+		{`command="ls" ` + sshEd25519, "", ErrSSHKey},
+		{sshEd25519 + "\nssh-ed25519 AAAA", "", ErrSSHKey},
+		{"", "", nil},
+		{sshEd25519, "dsa", ErrBadParameter},
+		{sshEd25519, "rsa:big", ErrBadParameter},
+		{1, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := sshpubkey(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,517 @@
+package govalidator
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrAlpha    = errors.New("not alphabetic")
+	ErrAlphanum = errors.New("not alphanumeric")
+	ErrNumeric  = errors.New("not numeric")
+	ErrDigits   = errors.New("invalid digits")
+	ErrASCII    = errors.New("not ascii")
+	ErrPrint    = errors.New("not printable")
+	ErrControl  = errors.New("contains control characters")
+
+	ErrLowercase = errors.New("not lowercase")
+	ErrUppercase = errors.New("not uppercase")
+	ErrTitlecase = errors.New("not titlecase")
+
+	ErrContains   = errors.New("missing required text")
+	ErrExcludes   = errors.New("contains excluded text")
+	ErrStartsWith = errors.New("missing required prefix")
+	ErrEndsWith   = errors.New("missing required suffix")
+
+	ErrUTF8 = errors.New("invalid utf-8")
+
+	ErrBlank     = errors.New("blank value")
+	ErrUntrimmed = errors.New("leading or trailing whitespace")
+
+	ErrRegexpSyntax = errors.New("invalid regular expression")
+	ErrRegexpSize   = errors.New("regular expression too large")
+)
+
+// checkRunes tests every rune of a string or *string with valid and
+// returns ruleErr on the first one it rejects. Empty strings are left to
+// nonzero.
+func checkRunes(v interface{}, valid func(rune) bool, ruleErr error) error {
+	s, ok, err := stringValue(v)
+	if !ok {
+		return err
+	}
+	for _, r := range s {
+		if !valid(r) {
+			return ruleErr
+		}
+	}
+	return nil
+}
+
+func isASCIILetter(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
+
+func isASCIIDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// alpha tests whether a string only holds ASCII letters.
+func alpha(v interface{}, param string) error {
+	return checkRunes(v, isASCIILetter, ErrAlpha)
+}
+
+// alphanum tests whether a string only holds ASCII letters and digits.
+func alphanum(v interface{}, param string) error {
+	return checkRunes(v, func(r rune) bool {
+		return isASCIILetter(r) || isASCIIDigit(r)
+	}, ErrAlphanum)
+}
+
+// alphaunicode tests whether a string only holds Unicode letters.
+func alphaunicode(v interface{}, param string) error {
+	return checkRunes(v, unicode.IsLetter, ErrAlpha)
+}
+
+// alphanumunicode tests whether a string only holds Unicode letters and
+// numbers.
+func alphanumunicode(v interface{}, param string) error {
+	return checkRunes(v, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsNumber(r)
+	}, ErrAlphanum)
+}
+
+// numeric tests whether a string holds a decimal number with an optional
+// sign and fraction, such as "-12", "+3.14" or ".5". Exponents aren't
+// accepted.
+func numeric(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if !isDecimal(s) {
+		return ErrNumeric
+	}
+	return nil
+}
+
+func isDecimal(s string) bool {
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	intPart, frac, _ := strings.Cut(s, ".")
+	if intPart == "" && frac == "" {
+		return false
+	}
+	for _, part := range []string{intPart, frac} {
+		for i := 0; i < len(part); i++ {
+			if part[i] < '0' || part[i] > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// digits tests whether a string only holds ASCII digits, exactly as many
+// as the parameter says if given, e.g. digits=6 for OTP codes. Unlike
+// numeric it keeps leading zeros meaningful and rejects signs.
+func digits(v interface{}, param string) error {
+	n := int64(-1)
+	if param != "" {
+		var err error
+		if n, err = asInt(param); err != nil || n <= 0 {
+			return ErrBadParameter
+		}
+	}
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if n >= 0 && int64(len(s)) != n {
+		return ErrDigits
+	}
+	for _, r := range s {
+		if !isASCIIDigit(r) {
+			return ErrDigits
+		}
+	}
+	return nil
+}
+
+// ascii tests whether a string only holds ASCII characters.
+func ascii(v interface{}, param string) error {
+	return checkRunes(v, func(r rune) bool {
+		return r < utf8.RuneSelf
+	}, ErrASCII)
+}
+
+// printascii tests whether a string only holds printable ASCII
+// characters, from space to '~', as header values and identifiers do.
+func printascii(v interface{}, param string) error {
+	return checkRunes(v, func(r rune) bool {
+		return r >= ' ' && r <= '~'
+	}, ErrPrint)
+}
+
+// printable tests whether a string only holds printable Unicode
+// characters as defined by unicode.IsPrint. The only space it accepts
+// is U+0020.
+func printable(v interface{}, param string) error {
+	return checkRunes(v, unicode.IsPrint, ErrPrint)
+}
+
+// nocontrol tests whether a string is free of control characters, C0,
+// DEL and C1 alike, including newlines and tabs.
+func nocontrol(v interface{}, param string) error {
+	return checkRunes(v, func(r rune) bool {
+		return !unicode.IsControl(r)
+	}, ErrControl)
+}
+
+// lowercase tests whether a string has no upper or title case letters.
+func lowercase(v interface{}, param string) error {
+	return checkRunes(v, func(r rune) bool {
+		return !unicode.IsUpper(r) && !unicode.IsTitle(r)
+	}, ErrLowercase)
+}
+
+// uppercase tests whether a string has no lower case letters.
+func uppercase(v interface{}, param string) error {
+	return checkRunes(v, func(r rune) bool {
+		return !unicode.IsLower(r)
+	}, ErrUppercase)
+}
+
+// titlecase tests whether every word of a string starts with an upper
+// or title case letter followed by lower case ones, e.g. "Hello World".
+// Words are runs of letters.
+func titlecase(v interface{}, param string) error {
+	inWord := false
+	return checkRunes(v, func(r rune) bool {
+		if !unicode.IsLetter(r) {
+			inWord = false
+			return true
+		}
+		start := !inWord
+		inWord = true
+		if start {
+			return unicode.IsUpper(r) || unicode.IsTitle(r)
+		}
+		return !unicode.IsUpper(r) && !unicode.IsTitle(r)
+	}, ErrTitlecase)
+}
+
+// The substring rules take their parameter verbatim, commas included.
+// A ';' in it is written "\\;" inside the struct tag, see parseTag.
+
+// contains tests whether a string contains the parameter, e.g. contains=@.
+func contains(v interface{}, param string) error {
+	return checkString(v, func(s string) bool { return strings.Contains(s, param) }, ErrContains)
+}
+
+// containsany tests whether a string contains any of the characters of
+// the parameter.
+func containsany(v interface{}, param string) error {
+	return checkString(v, func(s string) bool { return strings.ContainsAny(s, param) }, ErrContains)
+}
+
+// excludes tests whether a string doesn't contain the parameter.
+func excludes(v interface{}, param string) error {
+	return checkString(v, func(s string) bool { return !strings.Contains(s, param) }, ErrExcludes)
+}
+
+// excludesall tests whether a string contains none of the characters of
+// the parameter, e.g. excludesall=<>"'.
+func excludesall(v interface{}, param string) error {
+	return checkString(v, func(s string) bool { return !strings.ContainsAny(s, param) }, ErrExcludes)
+}
+
+// startswith tests whether a string starts with the parameter.
+func startswith(v interface{}, param string) error {
+	return checkString(v, func(s string) bool { return strings.HasPrefix(s, param) }, ErrStartsWith)
+}
+
+// endswith tests whether a string ends with the parameter.
+func endswith(v interface{}, param string) error {
+	return checkString(v, func(s string) bool { return strings.HasSuffix(s, param) }, ErrEndsWith)
+}
+
+// notblank tests whether a string or *string has something besides
+// whitespace. Unlike nonzero it rejects "  ", and nil pointers.
+func notblank(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+	if !ok || strings.TrimSpace(s) == "" {
+		return ErrBlank
+	}
+	return nil
+}
+
+// notrim tests whether a string has no leading or trailing whitespace.
+func notrim(v interface{}, param string) error {
+	return checkString(v, func(s string) bool { return strings.TrimSpace(s) == s }, ErrUntrimmed)
+}
+
+// isregex tests whether a string compiles as a regular expression. The
+// optional param limits the size of the compiled program in
+// instructions, so that stored patterns stay cheap to evaluate.
+func isregex(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	limit := int64(-1)
+	if param != "" {
+		if limit, err = asInt(param); err != nil || limit < 0 {
+			return ErrBadParameter
+		}
+	}
+	if _, err := regexp.Compile(s); err != nil {
+		return ErrRegexpSyntax
+	}
+	if limit < 0 {
+		return nil
+	}
+	re, err := syntax.Parse(s, syntax.Perl)
+	if err != nil {
+		return ErrRegexpSyntax
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return ErrRegexpSyntax
+	}
+	if int64(len(prog.Inst)) > limit {
+		return ErrRegexpSize
+	}
+	return nil
+}
+
+// checkString tests a string or *string with valid and returns ruleErr
+// if it's rejected. Empty strings are left to nonzero.
+func checkString(v interface{}, valid func(string) bool, ruleErr error) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	if !valid(s) {
+		return ruleErr
+	}
+	return nil
+}
+
+// validUTF8 tests whether a string or []byte is valid UTF-8.
+func validUTF8(v interface{}, param string) error {
+	st := reflect.ValueOf(v)
+	if st.Kind() == reflect.Ptr {
+		if st.IsNil() {
+			return nil
+		}
+		st = st.Elem()
+	}
+	var valid bool
+	switch {
+	case st.Kind() == reflect.String:
+		valid = utf8.ValidString(st.String())
+	case st.Kind() == reflect.Slice && st.Type().Elem().Kind() == reflect.Uint8:
+		valid = utf8.Valid(st.Bytes())
+	default:
+		return ErrUnsupported
+	}
+	if !valid {
+		return ErrUTF8
+	}
+	return nil
+}
+
+func SetNormalizer(fn func(string) string) {
+	defaultValidator.SetNormalizer(fn)
+}
+
+// SetNormalizer sets a function applied to string values before the
+// rules see them, typically norm.NFC.String from golang.org/x/text so
+// that a composed and a decomposed "é" have the same len and match the
+// same enum. Fields themselves are left unchanged, as are strings that
+// aren't valid UTF-8 so that the utf8 rule still rejects them. A nil fn
+// turns normalization off.
+func (d *Validator) SetNormalizer(fn func(string) string) {
+	d.normalizer = fn
+}
+
+// normalize applies the normalizer to non-empty strings and *strings.
+// A *string gives a pointer to a normalized copy, so that rules still
+// see a pointer.
+func (d *Validator) normalize(value reflect.Value) reflect.Value {
+	if d.normalizer == nil {
+		return value
+	}
+	st := value
+	if st.Kind() == reflect.Ptr && !st.IsNil() {
+		st = st.Elem()
+	}
+	if st.Kind() != reflect.String || st.Len() == 0 || !utf8.ValidString(st.String()) {
+		return value
+	}
+	norm := reflect.ValueOf(d.normalizer(st.String())).Convert(st.Type())
+	if value.Kind() == reflect.Ptr {
+		ptr := reflect.New(st.Type())
+		ptr.Elem().Set(norm)
+		return ptr
+	}
+	return norm
+}
+
+// LengthMode selects how len, min, max and range count the characters
+// of strings.
+type LengthMode int
+
+const (
+	// LengthRunes counts Unicode code points, the default.
+	LengthRunes LengthMode = iota
+	// LengthGraphemes counts user-perceived characters, so that a
+	// family emoji made of several code points counts as one.
+	LengthGraphemes
+	// LengthBytes counts the bytes of the UTF-8 encoding.
+	LengthBytes
+)
+
+func SetLengthMode(mode LengthMode) {
+	defaultValidator.SetLengthMode(mode)
+}
+
+func (d *Validator) SetLengthMode(mode LengthMode) {
+	d.lengthMode = mode
+}
+
+// strLen counts the characters of s according to the length mode.
+func (d *Validator) strLen(s string) int {
+	switch d.lengthMode {
+	case LengthGraphemes:
+		return graphemeCount(s)
+	case LengthBytes:
+		return len(s)
+	}
+	return utf8.RuneCountInString(s)
+}
+
+func (d *Validator) length(v interface{}, param string) error {
+	return lengthOf(v, param, d.strLen)
+}
+
+func (d *Validator) min(v interface{}, param string) error {
+	return minOf(v, param, d.strLen)
+}
+
+func (d *Validator) max(v interface{}, param string) error {
+	return maxOf(v, param, d.strLen)
+}
+
+func (d *Validator) inRange(v interface{}, param string) error {
+	return inRangeOf(v, param, d.strLen)
+}
+
+// byteCount returns the number of bytes of a string or []byte. ok is
+// false for nil pointers.
+func byteCount(v interface{}) (n int, ok bool, err error) {
+	st := reflect.ValueOf(v)
+	if st.Kind() == reflect.Ptr {
+		if st.IsNil() {
+			return 0, false, nil
+		}
+		st = st.Elem()
+	}
+	if st.Kind() == reflect.String || (st.Kind() == reflect.Slice && st.Type().Elem().Kind() == reflect.Uint8) {
+		return st.Len(), true, nil
+	}
+	return 0, false, ErrUnsupported
+}
+
+// checkBytes compares the byte count of v with param using cmp.
+func checkBytes(v interface{}, param string, cmp func(n, p int64) bool, ruleErr error) error {
+	n, ok, err := byteCount(v)
+	if !ok {
+		return err
+	}
+	p, err := asInt(param)
+	if err != nil {
+		return ErrBadParameter
+	}
+	if !cmp(int64(n), p) {
+		return ruleErr
+	}
+	return nil
+}
+
+// bytelen tests whether a string or []byte is exactly param bytes long,
+// whatever the length mode.
+func bytelen(v interface{}, param string) error {
+	return checkBytes(v, param, func(n, p int64) bool { return n == p }, ErrLen)
+}
+
+// bytemin tests whether a string or []byte is at least param bytes long.
+func bytemin(v interface{}, param string) error {
+	return checkBytes(v, param, func(n, p int64) bool { return n >= p }, ErrMin)
+}
+
+// bytemax tests whether a string or []byte is at most param bytes long,
+// e.g. to fit a column limited in bytes rather than characters.
+func bytemax(v interface{}, param string) error {
+	return checkBytes(v, param, func(n, p int64) bool { return n <= p }, ErrMax)
+}
+
+// graphemeCount approximates the number of extended grapheme clusters
+// of UAX #29 in s. Combining marks, variation selectors, emoji modifiers
+// and tags, Hangul vowel and trailing jamo, zero width joiner sequences,
+// pairs of regional indicators and CR LF all count as one character
+// with what precedes them.
+func graphemeCount(s string) int {
+	n := 0
+	var prev rune
+	regional := 0
+	for i, r := range s {
+		switch {
+		case i == 0:
+			n++
+		case prev == '\r' && r == '\n':
+		case prev == 0x200d && !isGraphemeExtend(r):
+			// emoji joined by a zero width joiner
+		case isGraphemeExtend(r):
+		case isRegionalIndicator(r) && isRegionalIndicator(prev) && regional%2 == 1:
+		default:
+			n++
+		}
+		if isRegionalIndicator(r) {
+			regional++
+		} else {
+			regional = 0
+		}
+		prev = r
+	}
+	return n
+}
+
+func isGraphemeExtend(r rune) bool {
+	switch {
+	case unicode.Is(unicode.M, r),
+		r == 0x200d,                  // zero width joiner
+		r >= 0xfe00 && r <= 0xfe0f,   // variation selectors
+		r >= 0xe0100 && r <= 0xe01ef, // variation selectors supplement
+		r >= 0x1f3fb && r <= 0x1f3ff, // emoji skin tone modifiers
+		r >= 0xe0020 && r <= 0xe007f, // tags
+		r >= 0x1160 && r <= 0x11ff,   // Hangul vowel and trailing jamo
+		r >= 0xd7b0 && r <= 0xd7ff:
+		return true
+	}
+	return false
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
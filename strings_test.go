@@ -0,0 +1,312 @@
+package govalidator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAlpha(t *testing.T) {
+	tests := []struct {
+		fn  ValidateFunc
+		v   interface{}
+		err error
+	}{
+		{alpha, "Gopher", nil},
+		{alpha, "", nil},
+		{alpha, "Go1", ErrAlpha},
+		{alpha, "Gö", ErrAlpha},
+		{alphanum, "Go118", nil},
+		{alphanum, "Go 1", ErrAlphanum},
+		{alphaunicode, "Gödel", nil},
+		{alphaunicode, "日本語", nil},
+		{alphaunicode, "Gödel1", ErrAlpha},
+		{alphanumunicode, "Gödel١٢", nil},
+		{alphanumunicode, "Gödel-1", ErrAlphanum},
+		{alpha, 1, ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, ""); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+func TestNumericAndDigits(t *testing.T) {
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{numeric, "123", "", nil},
+		{numeric, "-12.50", "", nil},
+		{numeric, "+.5", "", nil},
+		{numeric, "7.", "", nil},
+		{numeric, ".", "", ErrNumeric},
+		{numeric, "-", "", ErrNumeric},
+		{numeric, "1e5", "", ErrNumeric},
+		{numeric, "1.2.3", "", ErrNumeric},
+		{numeric, " 1", "", ErrNumeric},
+		{digits, "004211", "6", nil},
+		{digits, "04211", "6", ErrDigits},
+		{digits, "0042a1", "6", ErrDigits},
+		{digits, "123456789", "", nil},
+		{digits, "-1", "", ErrDigits},
+		{digits, "١٢٣", "", ErrDigits},
+		{digits, "1", "x", ErrBadParameter},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+func TestASCIIAndControl(t *testing.T) {
+	tests := []struct {
+		fn  ValidateFunc
+		v   interface{}
+		err error
+	}{
+		{ascii, "plain text\t~", nil},
+		{ascii, "café", ErrASCII},
+		{printascii, "Bearer abc.def", nil},
+		{printascii, "line\nbreak", ErrPrint},
+		{printascii, "\x7f", ErrPrint},
+		{printable, "héllo wörld ✓", nil},
+		{printable, "tab\there", ErrPrint},
+		{printable, "zero\u200bwidth", ErrPrint},
+		{nocontrol, "héllo wörld", nil},
+		{nocontrol, "bell\a", ErrControl},
+		{nocontrol, "c1\u0085", ErrControl},
+		{nocontrol, "", nil},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, ""); err != tt.err {
+			t.Errorf("%d: %q: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+func TestLetterCase(t *testing.T) {
+	tests := []struct {
+		fn  ValidateFunc
+		v   interface{}
+		err error
+	}{
+		{lowercase, "my-slug-2", nil},
+		{lowercase, "straße", nil},
+		{lowercase, "My-slug", ErrLowercase},
+		{lowercase, "ǅ", ErrLowercase},
+		{uppercase, "DE", nil},
+		{uppercase, "ÉCOLE 42", nil},
+		{uppercase, "De", ErrUppercase},
+		{titlecase, "Hello World", nil},
+		{titlecase, "Élan Vital, 2nd Ed.", ErrTitlecase},
+		{titlecase, "Jean-Luc O'Neil", nil},
+		{titlecase, "Hello world", ErrTitlecase},
+		{titlecase, "HEllo", ErrTitlecase},
+		{titlecase, "", nil},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, ""); err != tt.err {
+			t.Errorf("%d: %q: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+type Upload struct {
+	Owner   string `valid:"contains=@"`
+	Title   string `valid:"excludesall=<>\"'"`
+	OrderID string `valid:"startswith=ord_"`
+	File    string `valid:"endswith=.pdf"`
+	Query   string `valid:"excludes=a\\;b,c"`
+}
+
+func TestSubstringRules(t *testing.T) {
+	v := New()
+	resp, _ := v.Validate(Upload{Owner: "ice@pig", Title: "Q3 report", OrderID: "ord_42", File: "q3.pdf", Query: "a;b"})
+	if len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+	resp, _ = v.Validate(Upload{Owner: "icepig", Title: "<b>Q3</b>", OrderID: "42", File: "q3.pdf.exe", Query: "x a;b,c"})
+	want := Error{"Owner": ErrContains, "Title": ErrExcludes, "OrderID": ErrStartsWith, "File": ErrEndsWith, "Query": ErrExcludes}
+	if !reflect.DeepEqual(resp, want) {
+		t.Fatalf("resp: %v", resp)
+	}
+	if err := containsany("a+b", "+-"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type Dish struct {
+	Name   string  `valid:"utf8;max=4"`
+	Origin *string `valid:"enum=Café,Bistro"`
+}
+
+func TestUTF8AndNormalizer(t *testing.T) {
+	v := New()
+	resp, _ := v.Validate(Dish{Name: "ok\xff"})
+	if resp["Name"] != ErrUTF8 {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	// decomposed "é", a toy stand-in for norm.NFC.String
+	decomposed := "Cafe\u0301"
+	v.SetNormalizer(func(s string) string { return strings.ReplaceAll(s, "e\u0301", "\u00e9") })
+	resp, _ = v.Validate(Dish{Name: decomposed, Origin: &decomposed})
+	if len(resp) != 0 || decomposed != "Cafe\u0301" {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	// siblings compared by field rules are normalized too, and a *string
+	// stays a pointer
+	v.fieldFuncs["samefield"] = func(v, other interface{}) error {
+		if s, ok := v.(*string); !ok || *s != other {
+			return ErrBadParameter
+		}
+		return nil
+	}
+	type Rename struct {
+		Name    string
+		Confirm *string `valid:"samefield=Name"`
+	}
+	composed := "Caf\u00e9"
+	resp, _ = v.Validate(Rename{Name: decomposed, Confirm: &composed})
+	if len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	v.SetNormalizer(nil)
+	resp, _ = v.Validate(Rename{Name: decomposed, Confirm: &composed})
+	if resp["Confirm"] != ErrBadParameter {
+		t.Fatalf("resp: %v", resp)
+	}
+	resp, _ = v.Validate(Dish{Name: decomposed, Origin: &decomposed})
+	if resp["Name"] != ErrMax || !errors.Is(resp["Origin"], ErrEnum) {
+		t.Fatalf("resp: %v", resp)
+	}
+	if err := validUTF8([]byte("ok\xff"), ""); err != ErrUTF8 {
+		t.Fatalf("expected ErrUTF8, got %v", err)
+	}
+}
+
+func TestGraphemeCount(t *testing.T) {
+	tests := map[string]int{
+		"":        0,
+		"abc":     3,
+		"e\u0301": 1,
+		"\U0001F468\u200d\U0001F469\u200d\U0001F467": 1, // family
+		"\U0001F44D\U0001F3FD":                       1, // thumbs up, skin tone
+		"\U0001F1E9\U0001F1EA\U0001F1EB\U0001F1F7":   2, // two flags
+		"\U0001F1E9\U0001F1EA\U0001F1EB":             2,
+		"\u2764\ufe0f":                               1,
+		"\r\n":                                       1,
+		"\ud55c\uad6d\uc5b4":                         3,
+		"\u1112\u1161\u11ab":                         1, // decomposed Hangul
+	}
+	for s, want := range tests {
+		if n := graphemeCount(s); n != want {
+			t.Errorf("%q: expected %d, got %d", s, want, n)
+		}
+	}
+}
+
+type DisplayName struct {
+	Name string `valid:"max=3"`
+}
+
+func TestLengthMode(t *testing.T) {
+	v := New()
+	family := DisplayName{Name: "\U0001F468\u200d\U0001F469\u200d\U0001F467!"}
+	if resp, _ := v.Validate(family); resp["Name"] != ErrMax {
+		t.Fatalf("resp: %v", resp)
+	}
+	v.SetLengthMode(LengthGraphemes)
+	if resp, _ := v.Validate(family); len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+	v.SetLengthMode(LengthBytes)
+	if resp, _ := v.Validate(DisplayName{Name: "éé"}); resp["Name"] != ErrMax {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func TestByteLength(t *testing.T) {
+	var nilStr *string
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{bytelen, "abc", "3", nil},
+		{bytelen, "日本", "6", nil},
+		{bytelen, "日本", "2", ErrLen},
+		{bytelen, []byte{1, 2}, "2", nil},
+		{bytemin, "é", "2", nil},
+		{bytemin, "e", "2", ErrMin},
+		{bytemax, "ééé", "5", ErrMax},
+		{bytemax, "éé", "5", nil},
+		{bytemax, nilStr, "1", nil},
+		{bytemax, "a", "x", ErrBadParameter},
+		{bytemax, 5, "1", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+func TestBlankAndTrim(t *testing.T) {
+	var nilStr *string
+	spaces := "  "
+	tests := []struct {
+		fn  ValidateFunc
+		v   interface{}
+		err error
+	}{
+		{notblank, "a", nil},
+		{notblank, " a ", nil},
+		{notblank, "", ErrBlank},
+		{notblank, " \t\n", ErrBlank},
+		{notblank, &spaces, ErrBlank},
+		{notblank, nilStr, ErrBlank},
+		{notblank, 1, ErrUnsupported},
+		{notrim, "a b", nil},
+		{notrim, "", nil},
+		{notrim, " a", ErrUntrimmed},
+		{notrim, "a\n", ErrUntrimmed},
+		{notrim, nilStr, nil},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, ""); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+func TestIsRegex(t *testing.T) {
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{`^[a-z]+$`, "", nil},
+		{"", "", nil},
+		{`(foo`, "", ErrRegexpSyntax},
+		{`a{2000}`, "", ErrRegexpSyntax},
+		{`^abc$`, "20", nil},
+		{`(a|b|c|d){50}`, "20", ErrRegexpSize},
+		{`a`, "x", ErrBadParameter},
+		{1, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := isregex(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
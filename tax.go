@@ -0,0 +1,325 @@
+package govalidator
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrVAT        = errors.New("invalid vat number")
+	ErrVATCountry = errors.New("vat number country not allowed")
+	ErrSSN        = errors.New("invalid social security number")
+	ErrEIN        = errors.New("invalid employer identification number")
+)
+
+// vatFormats are the structures of VAT numbers after their country
+// prefix, which is EL for Greece and XI for Northern Ireland.
+var vatFormats = map[string]*regexp.Regexp{
+	"AT": regexp.MustCompile(`^U\d{8}$`),
+	"BE": regexp.MustCompile(`^[01]\d{9}$`),
+	"BG": regexp.MustCompile(`^\d{9,10}$`),
+	"CY": regexp.MustCompile(`^\d{8}[A-Z]$`),
+	"CZ": regexp.MustCompile(`^\d{8,10}$`),
+	"DE": regexp.MustCompile(`^\d{9}$`),
+	"DK": regexp.MustCompile(`^\d{8}$`),
+	"EE": regexp.MustCompile(`^\d{9}$`),
+	"EL": regexp.MustCompile(`^\d{9}$`),
+	"ES": regexp.MustCompile(`^([A-Z]\d{7}[A-Z0-9]|\d{8}[A-Z])$`),
+	"FI": regexp.MustCompile(`^\d{8}$`),
+	"FR": regexp.MustCompile(`^[0-9A-HJ-NP-Z]{2}\d{9}$`),
+	"HR": regexp.MustCompile(`^\d{11}$`),
+	"HU": regexp.MustCompile(`^\d{8}$`),
+	"IE": regexp.MustCompile(`^(\d{7}[A-W][A-IW]?|\d[A-Z+*]\d{5}[A-W])$`),
+	"IT": regexp.MustCompile(`^\d{11}$`),
+	"LT": regexp.MustCompile(`^(\d{9}|\d{12})$`),
+	"LU": regexp.MustCompile(`^\d{8}$`),
+	"LV": regexp.MustCompile(`^\d{11}$`),
+	"MT": regexp.MustCompile(`^\d{8}$`),
+	"NL": regexp.MustCompile(`^\d{9}B\d{2}$`),
+	"PL": regexp.MustCompile(`^\d{10}$`),
+	"PT": regexp.MustCompile(`^\d{9}$`),
+	"RO": regexp.MustCompile(`^[1-9]\d{1,9}$`),
+	"SE": regexp.MustCompile(`^\d{10}01$`),
+	"SI": regexp.MustCompile(`^[1-9]\d{7}$`),
+	"SK": regexp.MustCompile(`^[1-9]\d{9}$`),
+
+	"GB": regexp.MustCompile(`^(\d{9}|\d{12}|GD[0-4]\d{2}|HA[5-9]\d{2})$`),
+	"XI": regexp.MustCompile(`^(\d{9}|\d{12}|GD[0-4]\d{2}|HA[5-9]\d{2})$`),
+	"CH": regexp.MustCompile(`^E\d{9}(MWST|TVA|IVA)?$`),
+	"NO": regexp.MustCompile(`^\d{9}(MVA)?$`),
+}
+
+// vatChecksums verify the check digits of the countries that define
+// them in a way that can be checked offline.
+var vatChecksums = map[string]func(string) bool{
+	"AT": vatChecksumAT,
+	"BE": func(n string) bool { return 97-atoiDigits(n[:8])%97 == atoiDigits(n[8:]) },
+	"DE": vatChecksumDE,
+	"DK": func(n string) bool { return weightedSum(n, 2, 7, 6, 5, 4, 3, 2, 1)%11 == 0 },
+	"FI": func(n string) bool { return mod11Check(weightedSum(n, 7, 9, 10, 5, 8, 4, 2), n[7]) },
+	"FR": vatChecksumFR,
+	"IT": luhn,
+	"NL": vatChecksumNL,
+	"PL": func(n string) bool { return weightedSum(n, 6, 5, 7, 2, 3, 4, 5, 6, 7)%11 == int(n[9]-'0') },
+	"PT": func(n string) bool { return mod11Check(weightedSum(n, 9, 8, 7, 6, 5, 4, 3, 2), n[8]) },
+	"SE": func(n string) bool { return luhn(n[:10]) },
+	"GB": vatChecksumGB,
+	"XI": vatChecksumGB,
+}
+
+// euVATCountries are the VAT prefixes of the EU member states, which
+// vat=EU stands for.
+var euVATCountries = []string{
+	"AT", "BE", "BG", "CY", "CZ", "DE", "DK", "EE", "EL", "ES", "FI", "FR", "HR", "HU",
+	"IE", "IT", "LT", "LU", "LV", "MT", "NL", "PL", "PT", "RO", "SE", "SI", "SK",
+}
+
+// vat tests whether a string is a VAT identification number with its
+// country prefix, e.g. "DE136695976", ignoring spaces, dots and hyphens.
+// The structure is checked for every country, and so is the check digit
+// of those that define one. The parameters restrict the countries, e.g.
+// vat=DE,FR,GB, with EU standing for all member states.
+func vat(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	var allowed map[string]bool
+	for _, p := range SplitParams(param) {
+		if allowed == nil {
+			allowed = map[string]bool{}
+		}
+		p = strings.ToUpper(p)
+		switch {
+		case p == "EU":
+			for _, c := range euVATCountries {
+				allowed[c] = true
+			}
+		case p == "GR":
+			allowed["EL"] = true
+		case vatFormats[p] != nil:
+			allowed[p] = true
+		default:
+			return ErrBadParameter
+		}
+	}
+
+	s = strings.ToUpper(strings.NewReplacer(" ", "", ".", "", "-", "").Replace(s))
+	if len(s) < 3 {
+		return ErrVAT
+	}
+	country, number := s[:2], s[2:]
+	format, ok := vatFormats[country]
+	if !ok || !format.MatchString(number) {
+		return ErrVAT
+	}
+	if allowed != nil && !allowed[country] {
+		return ErrVATCountry
+	}
+	if check, ok := vatChecksums[country]; ok && !check(number) {
+		return ErrVAT
+	}
+	return nil
+}
+
+// atoiDigits returns the value of a short string of digits.
+func atoiDigits(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	return n
+}
+
+// weightedSum returns the sum of the leading digits of s multiplied by
+// the weights.
+func weightedSum(s string, weights ...int) int {
+	sum := 0
+	for i, w := range weights {
+		sum += int(s[i]-'0') * w
+	}
+	return sum
+}
+
+// mod11Check reports whether check is the mod 11 check digit of sum, 0
+// when the remainder is 0, 11 minus the remainder otherwise.
+func mod11Check(sum int, check byte) bool {
+	c := 11 - sum%11
+	if c == 11 {
+		c = 0
+	}
+	if c == 10 {
+		return false
+	}
+	return c == int(check-'0')
+}
+
+func vatChecksumAT(n string) bool {
+	sum := 0
+	for i := 1; i < 8; i++ {
+		d := int(n[i] - '0')
+		if i%2 == 0 {
+			d = d*2/10 + d*2%10
+		}
+		sum += d
+	}
+	return (96-sum)%10 == int(n[8]-'0')
+}
+
+// vatChecksumDE applies ISO 7064 MOD 11,10.
+func vatChecksumDE(n string) bool {
+	p := 10
+	for i := 0; i < 8; i++ {
+		s := (int(n[i]-'0') + p) % 10
+		if s == 0 {
+			s = 10
+		}
+		p = 2 * s % 11
+	}
+	c := 11 - p
+	if c == 10 {
+		c = 0
+	}
+	return c == int(n[8]-'0')
+}
+
+// vatChecksumFR checks numeric keys against the SIREN, the 9 digits
+// that follow. Alphabetic keys have no published algorithm.
+func vatChecksumFR(n string) bool {
+	if !isASCIIDigit(rune(n[0])) || !isASCIIDigit(rune(n[1])) {
+		return true
+	}
+	siren := 0
+	for i := 2; i < len(n); i++ {
+		siren = (siren*10 + int(n[i]-'0')) % 97
+	}
+	return (12+3*siren)%97 == atoiDigits(n[:2])
+}
+
+// vatChecksumNL accepts the mod 11 check of legal entities as well as
+// the mod 97 check of the numbers given to sole proprietors since 2020.
+func vatChecksumNL(n string) bool {
+	if mod97("NL"+n) == 1 {
+		return true
+	}
+	sum := weightedSum(n, 9, 8, 7, 6, 5, 4, 3, 2)
+	return sum%11 != 10 && sum%11 == int(n[8]-'0')
+}
+
+// vatChecksumGB checks the 9 digit standard numbers, and the first 9
+// digits of branch numbers, with the old and the 2010 mod 97 schemes.
+// Government and health authority numbers have no check digits.
+func vatChecksumGB(n string) bool {
+	if !isASCIIDigit(rune(n[0])) {
+		return true
+	}
+	sum := weightedSum(n, 8, 7, 6, 5, 4, 3, 2) + atoiDigits(n[7:9])
+	return sum%97 == 0 || (sum+55)%97 == 0
+}
+
+// dashedDigits returns the digits of s, which are grouped by sizes and
+// separated with hyphens. The param says whether the hyphens are
+// required (dashes), forbidden (nodashes) or optional (empty).
+func dashedDigits(s, param string, sizes ...int) (string, bool, error) {
+	switch param {
+	case "", "dashes", "nodashes":
+	default:
+		return "", false, ErrBadParameter
+	}
+	total := 0
+	for _, n := range sizes {
+		total += n
+	}
+	var digits string
+	switch {
+	case len(s) == total:
+		if param == "dashes" {
+			return "", false, nil
+		}
+		digits = s
+	case len(s) == total+len(sizes)-1:
+		if param == "nodashes" {
+			return "", false, nil
+		}
+		i := 0
+		for j, n := range sizes {
+			if j > 0 {
+				if s[i] != '-' {
+					return "", false, nil
+				}
+				i++
+			}
+			digits += s[i : i+n]
+			i += n
+		}
+	default:
+		return "", false, nil
+	}
+	for i := 0; i < len(digits); i++ {
+		if !isASCIIDigit(rune(digits[i])) {
+			return "", false, nil
+		}
+	}
+	return digits, true, nil
+}
+
+// ssn tests whether a string is a US Social Security Number such as
+// "123-45-6789", rejecting the area numbers 000, 666 and 9xx, group 00,
+// serial 0000 and numbers known to be void such as 078-05-1120. With
+// ssn=dashes the hyphens are required, with ssn=nodashes forbidden.
+func ssn(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	digits, ok, err := dashedDigits(s, param, 3, 2, 4)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrSSN
+	}
+	area, group, serial := digits[:3], digits[3:5], digits[5:]
+	if area == "000" || area == "666" || area[0] == '9' || group == "00" || serial == "0000" {
+		return ErrSSN
+	}
+	if digits == "078051120" || digits == "219099999" {
+		return ErrSSN
+	}
+	return nil
+}
+
+// einPrefixes are the prefixes the IRS assigns to Employer
+// Identification Numbers.
+var einPrefixes = map[string]bool{
+	"01": true, "02": true, "03": true, "04": true, "05": true, "06": true,
+	"10": true, "11": true, "12": true, "13": true, "14": true, "15": true, "16": true,
+	"20": true, "21": true, "22": true, "23": true, "24": true, "25": true, "26": true, "27": true,
+	"30": true, "31": true, "32": true, "33": true, "34": true, "35": true, "36": true, "37": true,
+	"38": true, "39": true, "40": true, "41": true, "42": true, "43": true, "44": true, "45": true,
+	"46": true, "47": true, "48": true, "50": true, "51": true, "52": true, "53": true, "54": true,
+	"55": true, "56": true, "57": true, "58": true, "59": true, "60": true, "61": true, "62": true,
+	"63": true, "64": true, "65": true, "66": true, "67": true, "68": true, "71": true, "72": true,
+	"73": true, "74": true, "75": true, "76": true, "77": true, "80": true, "81": true, "82": true,
+	"83": true, "84": true, "85": true, "86": true, "87": true, "88": true, "90": true, "91": true,
+	"92": true, "93": true, "94": true, "95": true, "98": true, "99": true,
+}
+
+// ein tests whether a string is a US Employer Identification Number such
+// as "12-3456789" with a prefix in use by the IRS. It takes the
+// parameters of ssn.
+func ein(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	digits, ok, err := dashedDigits(s, param, 2, 7)
+	if err != nil {
+		return err
+	}
+	if !ok || !einPrefixes[digits[:2]] {
+		return ErrEIN
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package govalidator
+
+import "testing"
+
+func TestVAT(t *testing.T) {
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"ATU13585627", "", nil},
+		{"BE0428759497", "", nil},
+		{"DE136695976", "", nil},
+		{"DE 136 695 976", "", nil},
+		{"de136695976", "", nil},
+		{"DK13585628", "", nil},
+		{"FI20774740", "", nil},
+		{"FR40303265045", "", nil},
+		{"IT00743110157", "", nil},
+		{"NL004495445B01", "", nil},
+		{"NL000000001B64", "", nil},
+		{"PL8567346215", "", nil},
+		{"PT501964843", "", nil},
+		{"GB980780684", "", nil},
+		{"GBGD001", "", nil},
+		{"ESX2482300W", "", nil},
+		{"EL094259216", "", nil},
+		{"CHE116281710MWST", "", nil},
+		{"", "", nil},
+		{"ATU13585626", "", ErrVAT},
+		{"BE0428759498", "", ErrVAT},
+		{"DE136695977", "", ErrVAT},
+		{"DE13669597", "", ErrVAT},
+		{"FR41303265045", "", ErrVAT},
+		{"IT00743110158", "", ErrVAT},
+		{"NL004495446B01", "", ErrVAT},
+		{"PL8567346216", "", ErrVAT},
+		{"GB980780685", "", ErrVAT},
+		{"US123456789", "", ErrVAT},
+		{"DE", "", ErrVAT},
+		{"DE136695976", "EU", nil},
+		{"GB980780684", "EU", ErrVATCountry},
+		{"GB980780684", "DE,FR,GB", nil},
+		{"EL094259216", "GR", nil},
+		{"DE136695976", "FR", ErrVATCountry},
+		{"DE136695976", "US", ErrBadParameter},
+		{136695976, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := vat(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+func TestSSNAndEIN(t *testing.T) {
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{ssn, "123-45-6789", "", nil},
+		{ssn, "123456789", "", nil},
+		{ssn, "123-45-6789", "dashes", nil},
+		{ssn, "123456789", "dashes", ErrSSN},
+		{ssn, "123456789", "nodashes", nil},
+		{ssn, "123-45-6789", "nodashes", ErrSSN},
+		{ssn, "", "", nil},
+		{ssn, "123-456789", "", ErrSSN},
+		{ssn, "12-345-6789", "", ErrSSN},
+		{ssn, "123-45-678a", "", ErrSSN},
+		{ssn, "000-45-6789", "", ErrSSN},
+		{ssn, "666-45-6789", "", ErrSSN},
+		{ssn, "900-45-6789", "", ErrSSN},
+		{ssn, "123-00-6789", "", ErrSSN},
+		{ssn, "123-45-0000", "", ErrSSN},
+		{ssn, "078-05-1120", "", ErrSSN},
+		{ssn, "123-45-6789", "spaces", ErrBadParameter},
+		{ein, "12-3456789", "", nil},
+		{ein, "123456789", "", nil},
+		{ein, "12-3456789", "dashes", nil},
+		{ein, "123456789", "dashes", ErrEIN},
+		{ein, "12-3456789", "nodashes", ErrEIN},
+		{ein, "07-3456789", "", ErrEIN},
+		{ein, "12-345678", "", ErrEIN},
+		{ein, "123-456789", "", ErrEIN},
+		{ein, 123456789, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,396 @@
+package govalidator
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrDateTime = errors.New("invalid date or time")
+	ErrRFC3339  = errors.New("invalid rfc3339 timestamp")
+	ErrRFC1123  = errors.New("invalid rfc1123 timestamp")
+	ErrBefore   = errors.New("not before the limit")
+	ErrAfter    = errors.New("not after the limit")
+	ErrMinAge   = errors.New("younger than minimum age")
+	ErrMaxAge   = errors.New("older than maximum age")
+	ErrUnixTime = errors.New("implausible unix timestamp")
+)
+
+var (
+	rfc3339Pattern     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)
+	rfc3339NanoPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d{1,9})?(Z|[+-]\d{2}:\d{2})$`)
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+
+	// timeNow is replaced in tests to get a stable "now".
+	timeNow = time.Now
+)
+
+// asTime returns the parameter as a time.Time. It accepts RFC3339
+// timestamps, dates such as "2020-01-01" meaning midnight UTC and the
+// keyword "now" with an optional duration offset, e.g. "now", "now+72h"
+// or "now-1h30m".
+func asTime(param string) (time.Time, error) {
+	param = strings.TrimSpace(param)
+	if strings.HasPrefix(param, "now") {
+		now := timeNow()
+		offset := strings.TrimSpace(param[len("now"):])
+		if offset == "" {
+			return now, nil
+		}
+		if offset[0] != '+' && offset[0] != '-' {
+			return time.Time{}, ErrBadParameter
+		}
+		d, err := time.ParseDuration(offset)
+		if err != nil {
+			return time.Time{}, ErrBadParameter
+		}
+		return now.Add(d), nil
+	}
+	t, ok := parseTimestamp(param)
+	if !ok {
+		return time.Time{}, ErrBadParameter
+	}
+	return t, nil
+}
+
+// parseTimestamp parses an RFC3339 timestamp or a date.
+func parseTimestamp(s string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		if t, err = time.Parse(time.DateOnly, s); err != nil {
+			return time.Time{}, false
+		}
+	}
+	return t, true
+}
+
+// compareTime compares a time.Time value with the time given as
+// parameter and returns -1, 0 or +1 like strings.Compare does.
+func compareTime(st reflect.Value, param string) (int, error) {
+	p, err := asTime(param)
+	if err != nil {
+		return 0, err
+	}
+	t := st.Interface().(time.Time)
+	switch {
+	case t.Before(p):
+		return -1, nil
+	case t.After(p):
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// asIntOf returns the parameter as an int64 for a value of type t.
+// time.Duration values take human readable params like "1s" or "5m"
+// which are converted to nanoseconds.
+func asIntOf(t reflect.Type, param string) (int64, error) {
+	if t == durationType {
+		d, err := time.ParseDuration(param)
+		if err != nil {
+			return 0, ErrBadParameter
+		}
+		return int64(d), nil
+	}
+	return asInt(param)
+}
+
+// namedLayouts are the layouts of package time that datetime accepts by
+// name.
+var namedLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+// datetime tests whether a string parses with one of the layouts given
+// as parameters, either Go reference layouts or the names of those of
+// package time, e.g. datetime=2006-01-02 or datetime=DateOnly,RFC3339.
+// Commas inside a layout are escaped: `valid:"datetime=Jan 2\\, 2006"`.
+func datetime(v interface{}, param string) error {
+	s, ok, err := stringValue(v)
+	if !ok || s == "" {
+		return err
+	}
+	layouts := SplitParams(param)
+	if len(layouts) == 0 {
+		return ErrBadParameter
+	}
+	for _, layout := range layouts {
+		if named, ok := namedLayouts[layout]; ok {
+			layout = named
+		}
+		if _, err := time.Parse(layout, s); err == nil {
+			return nil
+		}
+	}
+	return ErrDateTime
+}
+
+// rfc3339 tests whether a string is an RFC 3339 timestamp in the form
+// time.RFC3339 formats, "2006-01-02T15:04:05Z07:00": whole seconds and
+// a mandatory offset, with upper case 'T' and 'Z'.
+func rfc3339(v interface{}, param string) error {
+	return checkString(v, func(s string) bool {
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil && rfc3339Pattern.MatchString(s)
+	}, ErrRFC3339)
+}
+
+// rfc3339nano is rfc3339 allowing fractions of a second, up to
+// nanoseconds, as time.RFC3339Nano formats them.
+func rfc3339nano(v interface{}, param string) error {
+	return checkString(v, func(s string) bool {
+		_, err := time.Parse(time.RFC3339Nano, s)
+		return err == nil && rfc3339NanoPattern.MatchString(s)
+	}, ErrRFC3339)
+}
+
+// rfc1123 tests whether a string is an RFC 1123 timestamp as used by
+// HTTP, "Mon, 02 Jan 2006 15:04:05 GMT", with a zone abbreviation or a
+// numeric offset. The day of the week must match the date.
+func rfc1123(v interface{}, param string) error {
+	return checkString(v, func(s string) bool {
+		t, err := time.Parse(time.RFC1123, s)
+		if err != nil {
+			if t, err = time.Parse(time.RFC1123Z, s); err != nil {
+				return false
+			}
+		}
+		return strings.HasPrefix(s, t.Weekday().String()[:3]+",")
+	}, ErrRFC1123)
+}
+
+// timeValue returns the time held by a time.Time, *time.Time or an
+// RFC3339 or date string. ok is false for nil pointers and empty strings.
+func timeValue(v interface{}) (t time.Time, ok bool, err error) {
+	st := reflect.ValueOf(v)
+	if st.Kind() == reflect.Ptr {
+		if st.IsNil() {
+			return t, false, nil
+		}
+		st = st.Elem()
+	}
+	if !st.IsValid() {
+		return t, false, nil
+	}
+	switch {
+	case st.Type() == timeType:
+		return st.Interface().(time.Time), true, nil
+	case st.Kind() == reflect.String:
+		if st.Len() == 0 {
+			return t, false, nil
+		}
+		if t, ok = parseTimestamp(st.String()); !ok {
+			return t, false, ErrDateTime
+		}
+		return t, true, nil
+	}
+	return t, false, ErrUnsupported
+}
+
+// before tests whether a time, or a timestamp string, is strictly before
+// the time given as parameter, e.g. before=now+72h or before=2030-01-01.
+func before(v interface{}, param string) error {
+	limit, err := asTime(param)
+	if err != nil {
+		return err
+	}
+	t, ok, err := timeValue(v)
+	if !ok {
+		return err
+	}
+	if !t.Before(limit) {
+		return ErrBefore
+	}
+	return nil
+}
+
+// after tests whether a time, or a timestamp string, is strictly after
+// the time given as parameter, e.g. after=now for future times only.
+func after(v interface{}, param string) error {
+	limit, err := asTime(param)
+	if err != nil {
+		return err
+	}
+	t, ok, err := timeValue(v)
+	if !ok {
+		return err
+	}
+	if !t.After(limit) {
+		return ErrAfter
+	}
+	return nil
+}
+
+// age returns the number of whole years between birth and now, counting
+// dates in the location of birth. Someone born on February 29 turns a
+// year older on March 1 in common years.
+func age(birth, now time.Time) int {
+	now = now.In(birth.Location())
+	years := now.Year() - birth.Year()
+	if now.Month() < birth.Month() || now.Month() == birth.Month() && now.Day() < birth.Day() {
+		years--
+	}
+	return years
+}
+
+func checkAge(v interface{}, param string) (int, bool, error) {
+	limit, err := strconv.Atoi(param)
+	if err != nil || limit < 0 {
+		return 0, false, ErrBadParameter
+	}
+	birth, ok, err := timeValue(v)
+	if !ok {
+		return 0, false, err
+	}
+	return age(birth, timeNow()) - limit, true, nil
+}
+
+// minage tests whether a birthdate, a time or a date string, is at least
+// the number of years given as parameter ago, e.g. minage=18.
+func minage(v interface{}, param string) error {
+	diff, ok, err := checkAge(v, param)
+	if !ok {
+		return err
+	}
+	if diff < 0 {
+		return ErrMinAge
+	}
+	return nil
+}
+
+// maxage tests whether a birthdate is at most the number of years given
+// as parameter ago. maxage=17 accepts anyone before their 18th birthday.
+func maxage(v interface{}, param string) error {
+	diff, ok, err := checkAge(v, param)
+	if !ok {
+		return err
+	}
+	if diff > 0 {
+		return ErrMaxAge
+	}
+	return nil
+}
+
+// unixMillisThreshold separates epoch seconds from epoch milliseconds
+// when the unit is auto-detected: 1e11 seconds is in year 5138 while 1e11
+// milliseconds is in 1973.
+const unixMillisThreshold = 1e11
+
+// unixMax is the first time that unixts considers implausible.
+var unixMax = time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// unixTime returns the time of an integer, or decimal string, holding a
+// unix timestamp in unit "s" or "ms", or auto-detected if unit is empty.
+// ok is false for nil pointers and empty strings.
+func unixTime(v interface{}, unit string) (t time.Time, ok bool, err error) {
+	if unit != "" && unit != "s" && unit != "ms" {
+		return t, false, ErrBadParameter
+	}
+	st := reflect.ValueOf(v)
+	if st.Kind() == reflect.Ptr {
+		if st.IsNil() {
+			return t, false, nil
+		}
+		st = st.Elem()
+	}
+	if !st.IsValid() {
+		return t, false, nil
+	}
+	var n int64
+	switch st.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = st.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if st.Uint() > math.MaxInt64 {
+			return t, false, ErrUnixTime
+		}
+		n = int64(st.Uint())
+	case reflect.String:
+		if st.Len() == 0 {
+			return t, false, nil
+		}
+		if !isDigits(st.String()) {
+			return t, false, ErrUnixTime
+		}
+		if n, err = strconv.ParseInt(st.String(), 10, 64); err != nil {
+			return t, false, ErrUnixTime
+		}
+	default:
+		return t, false, ErrUnsupported
+	}
+	if unit == "ms" || unit == "" && n >= unixMillisThreshold {
+		t = time.UnixMilli(n)
+	} else {
+		t = time.Unix(n, 0)
+	}
+	if n <= 0 || !t.Before(unixMax) {
+		return t, false, ErrUnixTime
+	}
+	return t, true, nil
+}
+
+// unixts tests whether an integer is a plausible unix timestamp, after
+// 1970 and before year 3000. The parameter is the unit, s or ms, which is
+// detected from the magnitude of the value if omitted.
+func unixts(v interface{}, param string) error {
+	_, _, err := unixTime(v, param)
+	return err
+}
+
+// unixtsBefore tests whether a unix timestamp is before the time given
+// as first parameter, in any form of before, e.g. unixts_before=now+1h.
+// The optional second parameter is the unit of unixts.
+func unixtsBefore(v interface{}, param string) error {
+	return checkUnixBound(v, param, ErrBefore)
+}
+
+// unixtsAfter tests whether a unix timestamp is after the time given as
+// first parameter, e.g. unixts_after=2020-01-01,ms.
+func unixtsAfter(v interface{}, param string) error {
+	return checkUnixBound(v, param, ErrAfter)
+}
+
+func checkUnixBound(v interface{}, param string, ruleErr error) error {
+	params := SplitParams(param)
+	if len(params) == 0 || len(params) > 2 {
+		return ErrBadParameter
+	}
+	limit, err := asTime(params[0])
+	if err != nil {
+		return err
+	}
+	unit := ""
+	if len(params) == 2 {
+		unit = params[1]
+	}
+	t, ok, err := unixTime(v, unit)
+	if !ok {
+		return err
+	}
+	if ruleErr == ErrBefore && !t.Before(limit) || ruleErr == ErrAfter && !t.After(limit) {
+		return ruleErr
+	}
+	return nil
+}
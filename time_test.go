@@ -0,0 +1,288 @@
+package govalidator
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTimeRules(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{nonzero, time.Time{}, "", ErrZeroValue},
+		{nonzero, now, "", nil},
+		{min, now, "now", nil},
+		{min, now, "now+1h", ErrMin},
+		{min, now, "2024-05-01T11:00:00Z", nil},
+		{max, now.Add(time.Hour), "now+30m", ErrMax},
+		{max, now, "now-1h30m", ErrMax},
+		{max, &now, "2024-05-01T14:00:00+02:00", nil},
+		{eq, now, "2024-05-01T14:00:00+02:00", nil},
+		{eq, now, "now-1s", ErrEq},
+		{min, now, "yesterday", ErrBadParameter},
+		{min, now, "now*2", ErrBadParameter},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+}
+
+func TestDurationRules(t *testing.T) {
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{min, 2 * time.Second, "1s", nil},
+		{min, 500 * time.Millisecond, "1s", ErrMin},
+		{max, 5 * time.Minute, "5m", nil},
+		{max, time.Hour, "5m", ErrMax},
+		{eq, 90 * time.Second, "1m30s", nil},
+		{length, time.Second, "1s", nil},
+		{min, time.Second, "1", ErrBadParameter},
+		{min, int64(2), "1", nil},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+}
+
+type Event struct {
+	Day  string `valid:"datetime=2006-01-02"`
+	When string `valid:"datetime=DateOnly,RFC3339"`
+	Long string `valid:"datetime=Jan 2\\, 2006"`
+}
+
+func TestDateTime(t *testing.T) {
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{"2024-05-01", "2006-01-02", nil},
+		{"2024-02-30", "2006-01-02", ErrDateTime},
+		{"2024-5-1", "2006-01-02", ErrDateTime},
+		{"01/05/2024", "2006-01-02", ErrDateTime},
+		{"", "2006-01-02", nil},
+		{"2024-05-01T12:00:00Z", "DateOnly,RFC3339", nil},
+		{"2024-05-01", "DateOnly,RFC3339", nil},
+		{"12:00", "DateOnly,RFC3339", ErrDateTime},
+		{"3:04PM", "Kitchen", nil},
+		{"2024-05-01", "", ErrBadParameter},
+		{20240501, "2006-01-02", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := datetime(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+
+	resp, err := New().Validate(Event{Day: "2024-05-01", When: "2024-05-01T12:00:00+02:00", Long: "May 1, 2024"})
+	if err != nil || len(resp) != 0 {
+		t.Fatalf("resp: %v, err: %v", resp, err)
+	}
+}
+
+func TestTimestampFormats(t *testing.T) {
+	tests := []struct {
+		fn  ValidateFunc
+		v   interface{}
+		err error
+	}{
+		{rfc3339, "2024-05-01T12:00:00Z", nil},
+		{rfc3339, "2024-05-01T12:00:00+02:00", nil},
+		{rfc3339, "", nil},
+		{rfc3339, "2024-05-01T12:00:00", ErrRFC3339},
+		{rfc3339, "2024-05-01T12:00:00.5Z", ErrRFC3339},
+		{rfc3339, "2024-05-01t12:00:00z", ErrRFC3339},
+		{rfc3339, "2024-05-01 12:00:00Z", ErrRFC3339},
+		{rfc3339, "2024-13-01T12:00:00Z", ErrRFC3339},
+		{rfc3339, "2024-05-01T25:00:00Z", ErrRFC3339},
+		{rfc3339nano, "2024-05-01T12:00:00.123456789Z", nil},
+		{rfc3339nano, "2024-05-01T12:00:00.5-07:00", nil},
+		{rfc3339nano, "2024-05-01T12:00:00Z", nil},
+		{rfc3339nano, "2024-05-01T12:00:00.1234567890Z", ErrRFC3339},
+		{rfc3339nano, "2024-05-01T12:00:00.Z", ErrRFC3339},
+		{rfc1123, "Wed, 01 May 2024 12:00:00 GMT", nil},
+		{rfc1123, "Wed, 01 May 2024 12:00:00 +0200", nil},
+		{rfc1123, "Thu, 01 May 2024 12:00:00 GMT", ErrRFC1123},
+		{rfc1123, "Wed, 1 May 2024 12:00:00 GMT", ErrRFC1123},
+		{rfc1123, "2024-05-01T12:00:00Z", ErrRFC1123},
+		{rfc1123, 1, ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, ""); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+}
+
+type Booking struct {
+	Start time.Time  `valid:"after=now;before=now+72h"`
+	End   *time.Time `valid:"after=2020-01-01"`
+	Until string     `valid:"before=2030-01-01"`
+}
+
+func TestBeforeAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{after, now.Add(time.Second), "now", nil},
+		{after, now, "now", ErrAfter},
+		{after, now.Add(-time.Hour), "now", ErrAfter},
+		{before, now, "now+72h", nil},
+		{before, now.Add(72 * time.Hour), "now+72h", ErrBefore},
+		{after, now, "2020-01-01", nil},
+		{before, now, "2020-01-01", ErrBefore},
+		{after, "2024-05-02T00:00:00+02:00", "now", nil},
+		{after, "2024-05-01", "now", ErrAfter},
+		{before, "2019-12-31", "2020-01-01", nil},
+		{before, "", "2020-01-01", nil},
+		{before, (*time.Time)(nil), "2020-01-01", nil},
+		{before, nil, "2020-01-01", nil},
+		{after, nil, "now", nil},
+		{before, "yesterday", "2020-01-01", ErrDateTime},
+		{before, now, "2020-13-01", ErrBadParameter},
+		{before, 12, "now", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+
+	end := now.AddDate(-5, 0, 0)
+	resp, _ := New().Validate(Booking{Start: now.AddDate(0, 0, 4), End: &end, Until: "2031-01-01T00:00:00Z"})
+	if resp["Start"] != ErrBefore || resp["End"] != ErrAfter || resp["Until"] != ErrBefore {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+type AgeCheck struct {
+	Birthdate string     `valid:"minage=18"`
+	Junior    *time.Time `valid:"maxage=17"`
+}
+
+func TestAge(t *testing.T) {
+	now := time.Date(2023, 2, 28, 23, 30, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	tokyo := time.FixedZone("JST", 9*3600)
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{minage, "2005-02-28", "18", nil},
+		{minage, "2005-03-01", "18", ErrMinAge},
+		// leap day birthdays come on March 1 in common years
+		{minage, "2004-02-29", "19", ErrMinAge},
+		{minage, "2004-02-29", "18", nil},
+		// already March 1 in Tokyo
+		{minage, time.Date(2005, 3, 1, 0, 0, 0, 0, tokyo), "18", nil},
+		{minage, "2005-03-01T00:00:00+09:00", "18", nil},
+		{maxage, "2005-03-01", "17", nil},
+		{maxage, "2005-02-28", "17", ErrMaxAge},
+		{maxage, "2005-02-28", "18", nil},
+		{minage, "", "18", nil},
+		{minage, nil, "18", nil},
+		{maxage, nil, "17", nil},
+		{minage, "28/02/2005", "18", ErrDateTime},
+		{minage, "2005-02-28", "-1", ErrBadParameter},
+		{minage, "2005-02-28", "", ErrBadParameter},
+		{minage, 2005, "18", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+
+	junior := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	resp, _ := New().Validate(AgeCheck{Birthdate: "2010-06-15", Junior: &junior})
+	if resp["Birthdate"] != ErrMinAge || resp["Junior"] != ErrMaxAge {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+type Ping struct {
+	SentAt     int64  `valid:"unixts_after=2020-01-01;unixts_before=now+1h"`
+	ReceivedAt uint64 `valid:"unixts=ms"`
+	Legacy     string `valid:"unixts=s"`
+}
+
+func TestUnixTimestamp(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	secs, millis := now.Unix(), now.UnixMilli()
+	tests := []struct {
+		fn    ValidateFunc
+		v     interface{}
+		param string
+		err   error
+	}{
+		{unixts, secs, "", nil},
+		{unixts, millis, "", nil},
+		{unixts, secs, "s", nil},
+		{unixts, millis, "ms", nil},
+		{unixts, millis, "s", ErrUnixTime},
+		{unixts, uint32(secs), "", nil},
+		{unixts, "1714564800", "", nil},
+		{unixts, "", "", nil},
+		{unixts, (*int64)(nil), "", nil},
+		{unixts, nil, "", nil},
+		{unixtsBefore, nil, "now", nil},
+		{unixtsAfter, nil, "now", nil},
+		{unixts, 0, "", ErrUnixTime},
+		{unixts, -1, "", ErrUnixTime},
+		{unixts, "-5", "", ErrUnixTime},
+		{unixts, "17e8", "", ErrUnixTime},
+		{unixts, int64(math.MaxInt64), "", ErrUnixTime},
+		{unixts, secs, "us", ErrBadParameter},
+		{unixts, 1.5, "", ErrUnsupported},
+		{unixtsAfter, secs, "now-1m", nil},
+		{unixtsAfter, millis, "now", ErrAfter},
+		{unixtsAfter, millis, "2020-01-01,ms", nil},
+		{unixtsBefore, secs, "now+1h", nil},
+		{unixtsBefore, secs + 7200, "now+1h", ErrBefore},
+		{unixtsBefore, secs, "2020-01-01", ErrBefore},
+		{unixtsBefore, 0, "now", ErrUnixTime},
+		{unixtsBefore, secs, "", ErrBadParameter},
+		{unixtsBefore, secs, "now,s,x", ErrBadParameter},
+		{unixtsBefore, secs, "tomorrow", ErrBadParameter},
+	}
+	for i, tt := range tests {
+		if err := tt.fn(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+
+	resp, _ := New().Validate(Ping{SentAt: secs + 86400, ReceivedAt: uint64(millis), Legacy: "42x"})
+	if len(resp) != 2 || resp["SentAt"] != ErrBefore || resp["Legacy"] != ErrUnixTime {
+		t.Fatalf("resp: %v", resp)
+	}
+}
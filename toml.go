@@ -0,0 +1,22 @@
+//go:build govalidator_toml
+
+package govalidator
+
+import "github.com/BurntSushi/toml"
+
+var tomlRule ValidateFunc = isTOML
+
+// isTOML tests whether a string or []byte is a well-formed TOML document.
+// It is only built with the govalidator_toml tag, which adds a dependency
+// on github.com/BurntSushi/toml.
+func isTOML(v interface{}, param string) error {
+	b, err := jsonBytes(v)
+	if err != nil || len(b) == 0 {
+		return err
+	}
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(b, &doc); err != nil {
+		return ErrTOML
+	}
+	return nil
+}
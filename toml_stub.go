@@ -0,0 +1,7 @@
+//go:build !govalidator_toml
+
+package govalidator
+
+// tomlRule is nil unless built with the govalidator_toml tag, leaving the
+// toml rule unknown.
+var tomlRule ValidateFunc
@@ -0,0 +1,28 @@
+//go:build govalidator_toml
+
+package govalidator
+
+import "testing"
+
+func TestTOML(t *testing.T) {
+	tests := []struct {
+		v   interface{}
+		err error
+	}{
+		{"title = \"app\"\n[server]\nport = 8080\n", nil},
+		{[]byte("a = [1, 2]"), nil},
+		{"[server]\nport = \n", ErrTOML},
+		{"a = 1\na = 2\n", ErrTOML},
+		{"key: value", ErrTOML},
+		{"", nil},
+		{1, ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := isTOML(tt.v, ""); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+	if _, ok := New().validateFuncs["toml"]; !ok {
+		t.Fatal("toml rule not registered")
+	}
+}
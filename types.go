@@ -0,0 +1,226 @@
+package govalidator
+
+import (
+	"database/sql/driver"
+	"net"
+	"net/url"
+	"reflect"
+)
+
+var (
+	valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	ipType     = reflect.TypeOf(net.IP{})
+	ipNetType  = reflect.TypeOf(net.IPNet{})
+	urlType    = reflect.TypeOf(url.URL{})
+)
+
+// CustomTypeFunc returns the value the rules should validate in place
+// of a field of a registered custom type, e.g. the string form of a
+// uuid.UUID or the *big.Rat held by a decimal.Decimal.
+type CustomTypeFunc func(field reflect.Value) interface{}
+
+func RegisterCustomTypeFunc(fn CustomTypeFunc, types ...interface{}) {
+	defaultValidator.RegisterCustomTypeFunc(fn, types...)
+}
+
+// RegisterCustomTypeFunc registers fn for the types of the given sample
+// values. A nil fn removes the registration.
+func (d *Validator) RegisterCustomTypeFunc(fn CustomTypeFunc, types ...interface{}) {
+	if d.customTypeFuncs == nil {
+		d.customTypeFuncs = map[reflect.Type]CustomTypeFunc{}
+	}
+	for _, t := range types {
+		if fn == nil {
+			delete(d.customTypeFuncs, reflect.TypeOf(t))
+			continue
+		}
+		d.customTypeFuncs[reflect.TypeOf(t)] = fn
+	}
+}
+
+// resolve converts field values of types the builtin rules don't know
+// about into values they do. Registered custom types are converted by
+// their CustomTypeFunc. The database/sql Null* types are unwrapped:
+// Valid=false behaves like a nil pointer and Valid=true yields the inner
+// value. net.IP, net.IPNet and url.URL are validated in their textual
+// form. Any other struct implementing driver.Valuer is validated by the
+// value it stores in the database, a nil one again behaving like a nil
+// pointer.
+func (d *Validator) resolve(value reflect.Value) (reflect.Value, error) {
+	if fn, field, ok := d.customTypeFunc(value); ok {
+		return nilAsPtr(reflect.ValueOf(fn(field)), field.Type()), nil
+	}
+	if s, ok := netValue(value); ok {
+		return s, nil
+	}
+	if value.Kind() == reflect.Ptr && !value.IsNil() && isSQLNull(value.Type().Elem()) {
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Struct && isSQLNull(value.Type()) {
+		inner := value.Field(0)
+		if value.Type().Field(0).Name == "Valid" {
+			inner = value.Field(1)
+		}
+		if !value.FieldByName("Valid").Bool() {
+			return reflect.Zero(reflect.PtrTo(inner.Type())), nil
+		}
+		return inner, nil
+	}
+	if value.Kind() == reflect.Struct && value.Type() != timeType {
+		return driverValue(value)
+	}
+	return value, nil
+}
+
+// driverValue returns the driver.Value of a struct implementing
+// driver.Valuer, with either a value or a pointer receiver. Other
+// values are returned unchanged.
+func driverValue(value reflect.Value) (reflect.Value, error) {
+	t := value.Type()
+	if !t.Implements(valuerType) {
+		if !reflect.PtrTo(t).Implements(valuerType) {
+			return value, nil
+		}
+		ptr := reflect.New(t)
+		ptr.Elem().Set(value)
+		value = ptr
+	}
+	dv, err := value.Interface().(driver.Valuer).Value()
+	if err != nil {
+		return value, err
+	}
+	return nilAsPtr(reflect.ValueOf(dv), t), nil
+}
+
+// nilAsPtr turns the zero Value, which a nil interface{} produces, into
+// a nil pointer to t so the rules treat it like any other nil pointer.
+func nilAsPtr(value reflect.Value, t reflect.Type) reflect.Value {
+	if !value.IsValid() {
+		return reflect.Zero(reflect.PtrTo(t))
+	}
+	return value
+}
+
+// isSQLNull reports whether t is one of the database/sql Null* types,
+// including the generic sql.Null[T].
+func isSQLNull(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t.PkgPath() != "database/sql" || t.NumField() != 2 {
+		return false
+	}
+	f, ok := t.FieldByName("Valid")
+	return ok && f.Type.Kind() == reflect.Bool
+}
+
+// customTypeFunc returns the CustomTypeFunc registered for the type of
+// value or, for non-nil pointers, the type it points to, along with the
+// value to pass it.
+func (d *Validator) customTypeFunc(value reflect.Value) (CustomTypeFunc, reflect.Value, bool) {
+	if len(d.customTypeFuncs) == 0 || !value.IsValid() {
+		return nil, value, false
+	}
+	if fn, ok := d.customTypeFuncs[value.Type()]; ok {
+		return fn, value, true
+	}
+	if value.Kind() == reflect.Ptr && !value.IsNil() {
+		fn, ok := d.customTypeFuncs[value.Type().Elem()]
+		return fn, value.Elem(), ok
+	}
+	return nil, value, false
+}
+
+// netValue returns the textual form of a net.IP, net.IPNet or url.URL
+// value, or of a pointer to one. Nil pointers, and IPs and networks
+// without an address, yield a nil *string so that required and nonzero
+// see them as missing. A zero url.URL yields "".
+func netValue(value reflect.Value) (reflect.Value, bool) {
+	if !value.IsValid() || !value.CanInterface() {
+		return value, false
+	}
+	absent := reflect.Zero(reflect.PtrTo(reflect.TypeOf("")))
+	if value.Kind() == reflect.Ptr {
+		if !isNetType(value.Type().Elem()) {
+			return value, false
+		}
+		if value.IsNil() {
+			return absent, true
+		}
+		value = value.Elem()
+	}
+	var s string
+	switch value.Type() {
+	case ipType:
+		ip := value.Interface().(net.IP)
+		if len(ip) == 0 {
+			return absent, true
+		}
+		s = ip.String()
+	case ipNetType:
+		n := value.Interface().(net.IPNet)
+		if len(n.IP) == 0 {
+			return absent, true
+		}
+		s = n.String()
+	case urlType:
+		u := value.Interface().(url.URL)
+		s = u.String()
+	default:
+		return value, false
+	}
+	return reflect.ValueOf(s), true
+}
+
+func isNetType(t reflect.Type) bool {
+	return t == ipType || t == ipNetType || t == urlType
+}
+
+// Validatable is implemented by structs with rules that span several of
+// their fields. Validate runs after the rules of the fields of a nested
+// struct and its error is reported under the name of the field. It isn't
+// run for the struct given to Validate itself, so that it can call
+// Validate on its receiver.
+type Validatable interface {
+	Validate() error
+}
+
+var validatableType = reflect.TypeOf((*Validatable)(nil)).Elem()
+
+// structLevelError runs the Validatable hook of the struct rv, with
+// either a value or a pointer receiver.
+func structLevelError(rv reflect.Value) error {
+	if !rv.Type().Implements(validatableType) {
+		if !reflect.PtrTo(rv.Type()).Implements(validatableType) {
+			return nil
+		}
+		if rv.CanAddr() {
+			rv = rv.Addr()
+		} else {
+			ptr := reflect.New(rv.Type())
+			ptr.Elem().Set(rv)
+			rv = ptr
+		}
+	}
+	return rv.Interface().(Validatable).Validate()
+}
+
+// isNestedStruct reports whether values of type t, or of the type it
+// points to, are structs whose fields are validated one by one rather
+// than as a single value like time.Time, sql.NullString or the types
+// handled by RegisterCustomTypeFunc.
+func (d *Validator) isNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		if _, ok := d.customTypeFuncs[t]; ok {
+			return false
+		}
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	if _, ok := d.customTypeFuncs[t]; ok {
+		return false
+	}
+	if t == timeType || isBigType(t) || isNetType(t) || isSQLNull(t) {
+		return false
+	}
+	return !t.Implements(valuerType) && !reflect.PtrTo(t).Implements(valuerType)
+}
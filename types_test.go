@@ -0,0 +1,165 @@
+package govalidator
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Account struct {
+	Nick    sql.NullString   `valid:"nonzero;min=3"`
+	Bio     sql.NullString   `valid:"max=5"`
+	Credits sql.NullInt64    `valid:"min=10"`
+	Rate    *sql.NullFloat64 `valid:"max=1"`
+	Since   sql.NullTime     `valid:"nonnil"`
+	Tier    sql.Null[int]    `valid:"enum=1,2,3"`
+}
+
+func TestSQLNullTypes(t *testing.T) {
+	v := newTestValidator()
+
+	resp, _ := v.Validate(Account{})
+	if len(resp) != 2 || resp["Nick"] != ErrZeroValue || resp["Since"] != ErrZeroValue {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	acc := Account{
+		Nick:    sql.NullString{String: "ab", Valid: true},
+		Bio:     sql.NullString{String: "too long", Valid: true},
+		Credits: sql.NullInt64{Int64: 5, Valid: true},
+		Rate:    &sql.NullFloat64{Float64: 1.5, Valid: true},
+		Since:   sql.NullTime{Time: time.Now(), Valid: true},
+		Tier:    sql.Null[int]{V: 4, Valid: true},
+	}
+	resp, _ = v.Validate(acc)
+	if resp["Nick"] != ErrMin || resp["Bio"] != ErrMax || resp["Credits"] != ErrMin ||
+		resp["Rate"] != ErrMax || resp["Since"] != nil || !errors.Is(resp["Tier"], ErrEnum) {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+type Money struct {
+	Cents    int64
+	Currency string
+}
+
+type Order struct {
+	Total    Money  `valid:"min=100;max=50000"`
+	Discount *Money `valid:"max=1000"`
+	Refund   *Money `valid:"nonnil"`
+}
+
+func TestRegisterCustomTypeFunc(t *testing.T) {
+	v := newTestValidator()
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		return field.Interface().(Money).Cents
+	}, Money{})
+
+	resp, _ := v.Validate(Order{Total: Money{Cents: 99}, Discount: &Money{Cents: 1001}})
+	if resp["Total"] != ErrMin || resp["Discount"] != ErrMax || resp["Refund"] != ErrZeroValue {
+		t.Fatalf("resp: %v", resp)
+	}
+	resp, _ = v.Validate(Order{Total: Money{Cents: 100}, Refund: &Money{}})
+	if len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	v.RegisterCustomTypeFunc(nil, Money{})
+	resp, _ = v.Validate(Order{Total: Money{Cents: 100}, Refund: &Money{}})
+	if resp["Total"] != ErrUnsupported {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+// Email is stored lower-cased, or as NULL when empty.
+type Email struct {
+	Addr string
+}
+
+func (e *Email) Value() (driver.Value, error) {
+	if e.Addr == "" {
+		return nil, nil
+	}
+	if e.Addr == "broken" {
+		return nil, errors.New("broken email")
+	}
+	return strings.ToLower(e.Addr), nil
+}
+
+type Contact struct {
+	Primary   Email `valid:"nonnil;regex=^[a-z]+@[a-z.]+$"`
+	Secondary Email `valid:"max=10"`
+}
+
+func TestDriverValuer(t *testing.T) {
+	v := newTestValidator()
+
+	resp, _ := v.Validate(Contact{Primary: Email{"ICE@Example.com"}})
+	if len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+	resp, _ = v.Validate(Contact{Secondary: Email{"someone@example.com"}})
+	if resp["Primary"] != ErrZeroValue || resp["Secondary"] != ErrMax {
+		t.Fatalf("resp: %v", resp)
+	}
+	resp, _ = v.Validate(Contact{Primary: Email{"broken"}})
+	if resp["Primary"] == nil || resp["Primary"].Error() != "broken email" {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+type Endpoint struct {
+	Addr    net.IP     `valid:"nonzero;enum=10.0.0.1,::1"`
+	Network *net.IPNet `valid:"regex=^10\\."`
+	Target  url.URL    `valid:"nonzero;regex=^https://"`
+	Backup  *url.URL   `valid:"regex=^https://"`
+}
+
+func TestNetTypes(t *testing.T) {
+	v := newTestValidator()
+
+	_, network, _ := net.ParseCIDR("10.1.0.0/16")
+	target, _ := url.Parse("https://example.com/hook")
+	resp, _ := v.Validate(Endpoint{Addr: net.ParseIP("10.0.0.1"), Network: network, Target: *target})
+	if len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	_, network, _ = net.ParseCIDR("192.168.0.0/24")
+	backup, _ := url.Parse("http://example.com")
+	resp, _ = v.Validate(Endpoint{Addr: net.ParseIP("10.0.0.2"), Network: network, Backup: backup})
+	if !errors.Is(resp["Addr"], ErrEnum) || resp["Network"] != ErrRegexp || resp["Target"] != ErrZeroValue || resp["Backup"] != ErrRegexp {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	resp, _ = v.Validate(Endpoint{})
+	if resp["Addr"] != ErrZeroValue {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	// IPs and networks without an address are missing
+	var nilIP net.IP
+	resp, _ = v.Validate(Gateway{Ref: &nilIP})
+	if len(resp) != 4 || resp["Addr"] != ErrRequired || resp["Network"] != ErrRequired ||
+		resp["Subnet"] != ErrRequired || resp["Ref"] != ErrRequired {
+		t.Fatalf("resp: %v", resp)
+	}
+	ip := net.ParseIP("::1")
+	resp, _ = v.Validate(Gateway{Addr: ip, Network: network, Subnet: *network, Ref: &ip})
+	if len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+type Gateway struct {
+	Addr    net.IP     `valid:"required"`
+	Network *net.IPNet `valid:"required"`
+	Subnet  net.IPNet  `valid:"required"`
+	Ref     *net.IP    `valid:"required"`
+}
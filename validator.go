@@ -3,29 +3,22 @@ package govalidator
 // ref https://github.com/go-validator/validator
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io/fs"
+	"net"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 )
 
 var (
-	defaultValidator = &Validator{
-		tagName: "valid",
-		validateFuncs: map[string]ValidateFunc{
-			"nonzero": nonzero,
-			"len":     length,
-			"min":     min,
-			"max":     max,
-			"regex":   regex,
-			"nonnil":  nonnil,
-			"enum":    enum,
-		},
-		errMap: map[string]ErrRuleMap{},
-	}
+	defaultValidator = New()
 
 	ErrNotSuport      = errors.New("unsuport validate type")
 	ErrZeroValue      = errors.New("not allowed zero")
@@ -39,6 +32,10 @@ var (
 	ErrInvalid        = errors.New("invalid value")
 	ErrCannotValidate = errors.New("cannot validate unexported struct")
 	ErrEnum           = errors.New("not allowed out of enum value")
+	ErrTypeMismatch   = errors.New("values are not of the same type")
+	ErrEq             = errors.New("not equal")
+	ErrRequired       = errors.New("required")
+	ErrRange          = errors.New("out of range")
 )
 
 type E struct {
@@ -50,13 +47,287 @@ type E struct {
 type ErrRuleMap map[string]string
 
 type Validator struct {
-	tagName       string
-	validateFuncs map[string]ValidateFunc
-	errMap        map[string]ErrRuleMap
+	tagName          string
+	modTagName       string
+	validateFuncs    map[string]ValidateFunc
+	ctxFuncs         map[string]ValidateCtxFunc
+	fieldFuncs       map[string]validateFieldFunc
+	errMap           map[string]ErrRuleMap
+	customTypeFuncs  map[reflect.Type]CustomTypeFunc
+	aliases          map[string]string
+	patterns         map[string]*regexp.Regexp
+	passwordPolicies map[string]PasswordPolicy
+	enums            map[string][]string
+	enumLabels       map[string]map[string]string
+	enumFuncs        map[string]EnumFunc
+	enumCache        *lookupCache
+	denyPatterns     map[string][]*regexp.Regexp
+	fsys             fs.FS
+	sanitizers       map[string]SanitizeFunc
+	htmlPolicies     map[string]HTMLPolicy
+	ignoreCase       bool
+	strict           bool
+	resolver         Resolver
+	mxCache          *lookupCache
+	hostCache        *lookupCache
+	httpClient       HTTPClient
+	urlCache         *lookupCache
+	pwned            PwnedPasswords
+	pwnedCache       *lookupCache
+	pwnedFailOpen    bool
+	jwtKeys          JWTKeys
+	disposable       DomainList
+	phoneFormats     map[string]PhoneFormat
+	phoneProvider    PhoneProvider
+	countries        *countryTable
+	businessHours    map[time.Weekday]OpeningHours
+	businessLocation *time.Location
+	holidays         HolidayCalendar
+	lookupTimeout    time.Duration
+	network          bool
+	normalizer       func(string) string
+	lengthMode       LengthMode
 }
 
 type ValidateFunc func(interface{}, string) error
 
+// ValidateCtxFunc is a ValidateFunc that also receives the context given
+// to ValidateContext, for rules that perform I/O.
+type ValidateCtxFunc func(context.Context, interface{}, string) error
+
+// validateFieldFunc is a rule comparing a field with another field of
+// the same struct, named by the parameter, e.g. subsetof=AllowedTags.
+type validateFieldFunc func(v, other interface{}) error
+
+// New returns a Validator using the "valid" tag name and the builtin
+// rules.
+func New() *Validator {
+	d := &Validator{
+		tagName:    "valid",
+		modTagName: "mod",
+		validateFuncs: map[string]ValidateFunc{
+			"nonzero":  nonzero,
+			"nonnil":   nonnil,
+			"required": required,
+			"eq":       eq,
+
+			"alpha":           alpha,
+			"alphanum":        alphanum,
+			"alphaunicode":    alphaunicode,
+			"alphanumunicode": alphanumunicode,
+			"numeric":         numeric,
+			"digits":          digits,
+			"ascii":           ascii,
+			"printascii":      printascii,
+			"printable":       printable,
+			"nocontrol":       nocontrol,
+			"lowercase":       lowercase,
+			"uppercase":       uppercase,
+			"titlecase":       titlecase,
+			"contains":        contains,
+			"containsany":     containsany,
+			"excludes":        excludes,
+			"excludesall":     excludesall,
+			"startswith":      startswith,
+			"endswith":        endswith,
+			"utf8":            validUTF8,
+			"notblank":        notblank,
+			"notrim":          notrim,
+			"isregex":         isregex,
+			"nohtml":          nohtml,
+			"bytelen":         bytelen,
+			"bytemin":         bytemin,
+			"bytemax":         bytemax,
+			"entropy":         entropy,
+
+			"base64":    base64Std,
+			"base64url": base64URL,
+			"base64raw": base64Raw,
+			"hex":       hexString,
+			"datauri":   dataURI,
+
+			"maxdecoded": maxdecoded,
+
+			"md5":    md5Digest,
+			"sha1":   sha1Digest,
+			"sha256": sha256Digest,
+			"sha384": sha384Digest,
+			"sha512": sha512Digest,
+
+			"json":       isJSON,
+			"jsonobject": isJSONObject,
+			"jsonarray":  isJSONArray,
+			"csv":        isCSV,
+			"xml":        isXML,
+
+			"uuid":   isUUID,
+			"ulid":   isULID,
+			"ksuid":  isKSUID,
+			"nanoid": isNanoID,
+
+			"url":      isURL,
+			"uri":      isURI,
+			"magnet":   magnet,
+			"mailto":   mailto,
+			"tel":      tel,
+			"hostname": hostname,
+			"fqdn":     fqdn,
+			"dnslabel": dnslabel,
+			"port":     isPort,
+			"hostport": hostport,
+
+			"ip":   isIP,
+			"ipv4": isIPv4,
+			"ipv6": isIPv6,
+
+			"cidr":   isCIDR,
+			"cidrv4": isCIDRv4,
+			"cidrv6": isCIDRv6,
+
+			"ip_in_cidr": ipInCIDR,
+
+			"creditcard": creditcard,
+			"iban":       iban,
+			"bic":        bic,
+			"isin":       isin,
+			"cusip":      cusip,
+			"amount":     amount,
+			"amountmin":  amountmin,
+			"amountmax":  amountmax,
+
+			"pem":      isPEM,
+			"x509cert": x509cert,
+			"pemkey":   pemkey,
+
+			"sshpubkey": sshpubkey,
+
+			"safepath": safepath,
+			"ext":      ext,
+			"mime":     mimeType,
+
+			"glob":        glob,
+			"matchesglob": matchesglob,
+
+			"unique": unique,
+			"sorted": sorted,
+
+			"nonilitems": nonilitems,
+
+			"haskeys":  haskeys,
+			"onlykeys": onlykeys,
+			"totalmax": totalmax,
+
+			"sum":    sum,
+			"summin": summin,
+			"summax": summax,
+			"avgmin": avgmin,
+			"avgmax": avgmax,
+
+			"imgmaxw":  imgmaxw,
+			"imgmaxh":  imgmaxh,
+			"imgminw":  imgminw,
+			"imgminh":  imgminh,
+			"imgratio": imgratio,
+
+			"bcrypt":   bcryptHash,
+			"argon2id": argon2id,
+			"scrypt":   scryptHash,
+			"phc":      phc,
+
+			"btc_addr": btcAddr,
+			"eth_addr": ethAddr,
+
+			"e164": e164,
+
+			"bcp47": bcp47,
+
+			"latitude":  latitude,
+			"longitude": longitude,
+
+			"datetime":    datetime,
+			"rfc3339":     rfc3339,
+			"rfc3339nano": rfc3339nano,
+			"rfc1123":     rfc1123,
+			"before":      before,
+			"after":       after,
+			"minage":      minage,
+			"maxage":      maxage,
+
+			"unixts":        unixts,
+			"unixts_before": unixtsBefore,
+			"unixts_after":  unixtsAfter,
+
+			"weekday": weekday,
+			"weekend": weekend,
+
+			"vat": vat,
+			"ssn": ssn,
+			"ein": ein,
+		},
+		ctxFuncs: map[string]ValidateCtxFunc{},
+		fieldFuncs: map[string]validateFieldFunc{
+			"subsetof":     subsetof,
+			"disjointwith": disjointwith,
+		},
+		errMap:     map[string]ErrRuleMap{},
+		sanitizers: builtinSanitizers(),
+		resolver:   net.DefaultResolver,
+		mxCache:    newLookupCache(defaultLookupTTL, defaultLookupEntries),
+		hostCache:  newLookupCache(defaultLookupTTL, defaultLookupEntries),
+		httpClient: publicHTTPClient,
+		urlCache:   newLookupCache(defaultLookupTTL, defaultLookupEntries),
+		pwnedCache: newLookupCache(defaultLookupTTL, pwnedCacheEntries),
+		enumCache:  newLookupCache(defaultLookupTTL, defaultLookupEntries),
+		disposable: disposableDomains,
+
+		phoneFormats: builtinPhoneFormats(),
+		htmlPolicies: builtinHTMLPolicies(),
+		denyPatterns: builtinDenyPatterns(),
+		countries:    newCountryTable(isoCountries),
+
+		businessHours: defaultBusinessHours(),
+
+		lookupTimeout: defaultLookupTimeout,
+	}
+	// rules depending on third-party parsers, built with their tags
+	if yamlRule != nil {
+		d.validateFuncs["yaml"] = yamlRule
+	}
+	if tomlRule != nil {
+		d.validateFuncs["toml"] = tomlRule
+	}
+	d.validateFuncs["len"] = d.length
+	d.validateFuncs["min"] = d.min
+	d.validateFuncs["max"] = d.max
+	d.validateFuncs["range"] = d.inRange
+	d.validateFuncs["regex"] = d.regex
+	d.validateFuncs["email_nodispose"] = d.emailNoDispose
+	d.validateFuncs["phone"] = d.phone
+	d.validateFuncs["country"] = d.country
+	d.validateFuncs["businessday"] = d.businessday
+	d.validateFuncs["businesshours"] = d.businesshours
+	d.validateFuncs["password"] = d.password
+	d.validateFuncs["jwt"] = d.jwt
+	d.validateFuncs["safehtml"] = d.safehtml
+	d.validateFuncs["denypattern"] = d.denypattern
+	d.validateFuncs["file"] = d.file
+	d.validateFuncs["dir"] = d.dir
+	d.validateFuncs["readable"] = d.readable
+	d.validateFuncs["enum"] = d.enum
+	d.validateFuncs["enumci"] = d.enumci
+	d.validateFuncs["eachenum"] = d.eachenum
+	d.validateFuncs["eachmin"] = d.eachmin
+	d.validateFuncs["eachmax"] = d.eachmax
+	d.validateFuncs["eachlen"] = d.eachlen
+	d.sanitizers["safehtml"] = d.sanitizeHTML
+	d.ctxFuncs["email"] = d.email
+	d.ctxFuncs["resolvable"] = d.resolvable
+	d.ctxFuncs["url_reachable"] = d.urlReachable
+	d.ctxFuncs["notpwned"] = d.notpwned
+	d.ctxFuncs["enumfn"] = d.enumfn
+	return d
+}
+
 type Error map[string]error
 
 func SetErr(le []E) {
@@ -67,14 +338,45 @@ func SetFunc(name string, fn ValidateFunc) {
 	defaultValidator.SetFunc(name, fn)
 }
 
+func SetCtxFunc(name string, fn ValidateCtxFunc) {
+	defaultValidator.SetCtxFunc(name, fn)
+}
+
 func SetTagName(tagName string) {
 	defaultValidator.SetTagName(tagName)
 }
 
+func SetAlias(name, rules string) {
+	defaultValidator.SetAlias(name, rules)
+}
+
+func SetIgnoreCase(ignoreCase bool) {
+	defaultValidator.SetIgnoreCase(ignoreCase)
+}
+
+func SetStrict(strict bool) {
+	defaultValidator.SetStrict(strict)
+}
+
+func SetPattern(name string, re *regexp.Regexp) {
+	defaultValidator.SetPattern(name, re)
+}
+
 func Validate(v interface{}) (Error, error) {
 	return defaultValidator.Validate(v)
 }
 
+func ValidateContext(ctx context.Context, v interface{}) (Error, error) {
+	return defaultValidator.ValidateContext(ctx, v)
+}
+
+// ValidateChanged validates only the fields of newV whose values differ
+// from the same fields of oldV. It also returns the names of the fields
+// that were checked. A nil oldV validates every field.
+func ValidateChanged(oldV, newV interface{}) (Error, []string, error) {
+	return defaultValidator.ValidateChanged(oldV, newV)
+}
+
 func (d *Validator) SetErr(le []E) {
 	for _, e := range le {
 		if _, ok := d.errMap[e.Field]; !ok {
@@ -95,6 +397,64 @@ func (d *Validator) SetFunc(name string, fn ValidateFunc) {
 	d.validateFuncs[name] = fn
 }
 
+// SetCtxFunc registers a context-aware rule. It takes precedence over a
+// ValidateFunc of the same name. A nil fn removes it.
+func (d *Validator) SetCtxFunc(name string, fn ValidateCtxFunc) {
+	if name == "" {
+		return
+	}
+	if fn == nil {
+		delete(d.ctxFuncs, name)
+		return
+	}
+	d.ctxFuncs[name] = fn
+}
+
+// SetAlias registers a reusable bundle of rules, so that a tag can say
+// `valid:"username"` instead of repeating "nonzero;min=3;max=32;...".
+// Empty rules remove the alias.
+func (d *Validator) SetAlias(name, rules string) {
+	if name == "" {
+		return
+	}
+	if rules == "" {
+		delete(d.aliases, name)
+		return
+	}
+	if d.aliases == nil {
+		d.aliases = map[string]string{}
+	}
+	d.aliases[name] = rules
+}
+
+// SetPattern registers a compiled regular expression that tags can
+// reference as regex=@name. A nil re removes the pattern.
+func (d *Validator) SetPattern(name string, re *regexp.Regexp) {
+	if name == "" {
+		return
+	}
+	if re == nil {
+		delete(d.patterns, name)
+		return
+	}
+	if d.patterns == nil {
+		d.patterns = map[string]*regexp.Regexp{}
+	}
+	d.patterns[name] = re
+}
+
+// SetIgnoreCase makes rule names in tags match case-insensitively, so
+// that `valid:"Min=3"` behaves like `valid:"min=3"`.
+func (d *Validator) SetIgnoreCase(ignoreCase bool) {
+	d.ignoreCase = ignoreCase
+}
+
+// SetStrict makes unknown rule names in tags fail the field with
+// ErrUnknownTag instead of being ignored.
+func (d *Validator) SetStrict(strict bool) {
+	d.strict = strict
+}
+
 func (d *Validator) SetTagName(tagName string) {
 	if tagName != "" {
 		d.tagName = tagName
@@ -102,56 +462,230 @@ func (d *Validator) SetTagName(tagName string) {
 }
 
 func (d *Validator) Validate(v interface{}) (Error, error) {
-	var err error
-	validErrs := make(Error)
-
-	rv := reflect.ValueOf(v)
+	return d.ValidateContext(context.Background(), v)
+}
 
-	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface && !rv.IsNil() {
-		rv = rv.Elem()
-	}
+// ValidateContext is like Validate but passes ctx to the rules added
+// with SetCtxFunc, such as those performing network lookups.
+func (d *Validator) ValidateContext(ctx context.Context, v interface{}) (Error, error) {
+	validErrs := make(Error)
 
+	rv := indirect(reflect.ValueOf(v))
 	if rv.Kind() != reflect.Struct {
 		return validErrs, ErrNotSuport
 	}
 
-	for i := 0; i < rv.NumField(); i++ {
-		field := rv.Type().Field(i)
+	// the Validatable hook of the struct itself isn't run, as it commonly
+	// calls Validate in turn
+	visiting := visitPointers(reflect.ValueOf(v), map[visit]bool{})
+	d.validateStruct(ctx, rv.Type().Name(), "", rv, false, validErrs, visiting)
+	return validErrs, nil
+}
 
-		value := rv.FieldByName(field.Name)
-		validErr := d.validateField(field, value)
-		if validErr != nil {
-			validErrs[field.Name] = validErr
+func (d *Validator) ValidateChanged(oldV, newV interface{}) (Error, []string, error) {
+	validErrs := make(Error)
+	checked := []string{}
+
+	nv := indirect(reflect.ValueOf(newV))
+	if nv.Kind() != reflect.Struct {
+		return validErrs, checked, ErrNotSuport
+	}
+	ov := indirect(reflect.ValueOf(oldV))
+	if ov.Kind() == reflect.Ptr && ov.IsNil() {
+		// a nil *T is no old value, as with a create
+		ov = reflect.Value{}
+	}
+	if ov.IsValid() && ov.Type() != nv.Type() {
+		return validErrs, checked, ErrTypeMismatch
+	}
+
+	visiting := visitPointers(reflect.ValueOf(newV), map[visit]bool{})
+	for i := 0; i < nv.NumField(); i++ {
+		if ov.IsValid() && !fieldChanged(ov.Field(i), nv.Field(i)) {
+			continue
 		}
+		checked = append(checked, nv.Type().Field(i).Name)
+		d.validateStructField(context.Background(), "", nv, i, validErrs, visiting)
+	}
+	return validErrs, checked, nil
+}
+
+// validateStruct validates the fields of rv, naming them after prefix,
+// then, if structLevel is set, runs its Validatable hook and reports
+// its error under name. visiting holds the pointers followed on the way
+// to rv.
+func (d *Validator) validateStruct(ctx context.Context, name, prefix string, rv reflect.Value, structLevel bool, validErrs Error, visiting map[visit]bool) {
+	for i := 0; i < rv.NumField(); i++ {
+		d.validateStructField(ctx, prefix, rv, i, validErrs, visiting)
+	}
+	if !structLevel {
+		return
+	}
+	if err := structLevelError(rv); err != nil {
+		validErrs[name] = err
 	}
-	return validErrs, err
 }
 
-func (d *Validator) validateField(field reflect.StructField, value reflect.Value) error {
+func (d *Validator) validateStructField(ctx context.Context, prefix string, rv reflect.Value, i int, validErrs Error, visiting map[visit]bool) {
+	field := rv.Type().Field(i)
 	tag := field.Tag.Get(d.tagName)
+	if field.PkgPath != "" {
+		if tag != "" {
+			validErrs[prefix+field.Name] = ErrCannotValidate
+		}
+		return
+	}
+	d.sanitizeField(field, rv.Field(i))
 
-	if tag == "" {
-		return nil
+	// untagged fields are only walked into when they hold a struct
+	// with rules of its own
+	if tag == "" && !d.isNestedStruct(field.Type) {
+		return
 	}
-	rules := strings.Split(tag, ";")
+	d.validateValue(ctx, prefix+field.Name, rv, field, rv.Field(i), d.parseTag(tag), validErrs, visiting)
+}
 
-	for _, rule := range rules {
-		rule := strings.TrimSpace(rule)
-		if rule == "" {
+// validateValue validates value, named name in the returned errors,
+// against the rules of rs and, when rs dives, each of its elements
+// against the rules of the next level. Nested structs are validated
+// field by field with their names prefixed by name, e.g. "Address.City".
+// parent is the struct holding field, whose other fields the rules
+// comparing fields refer to.
+func (d *Validator) validateValue(ctx context.Context, name string, parent reflect.Value, field reflect.StructField, value reflect.Value, rs *ruleSet, validErrs Error, visiting map[visit]bool) {
+	value, err := d.validateRules(ctx, parent, field, value, rs.rules)
+	if err != nil {
+		validErrs[name] = err
+		return
+	}
+
+	// a pointer already followed on the way here closes a cycle, such as
+	// a node pointing back to itself, and isn't walked into again
+	for v := value; (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && !v.IsNil(); v = v.Elem() {
+		if v.Kind() != reflect.Ptr {
 			continue
 		}
-		var ruleName string
-		var ruleValue string
-		var err error
-		pair := strings.Split(rule, "=")
-		if len(pair) > 0 {
-			ruleName = strings.TrimSpace(pair[0])
+		key := visit{v.Pointer(), v.Type()}
+		if visiting[key] {
+			return
+		}
+		visiting[key] = true
+		defer delete(visiting, key)
+	}
+	value = indirect(value)
+	if rs.dive == nil {
+		if !rs.structOnly && value.Kind() == reflect.Struct && d.isNestedStruct(value.Type()) {
+			d.validateStruct(ctx, name, name+".", value, !rs.noStructLevel, validErrs, visiting)
 		}
-		if len(pair) > 1 {
-			ruleValue = strings.TrimSpace(pair[1])
+		return
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			d.validateValue(ctx, fmt.Sprintf("%s[%d]", name, i), parent, field, value.Index(i), rs.dive, validErrs, visiting)
 		}
-		if fn, ok := d.validateFuncs[ruleName]; ok {
-			err = fn(value.Interface(), ruleValue)
+	case reflect.Map:
+		for _, key := range sortedKeys(value) {
+			elemName := fmt.Sprintf("%s[%v]", name, key.Interface())
+			// a key that fails its rules is reported under the
+			// name of its element, which is then not validated
+			if _, err := d.validateRules(ctx, parent, field, key, rs.dive.keys); err != nil {
+				validErrs[elemName] = err
+				continue
+			}
+			d.validateValue(ctx, elemName, parent, field, value.MapIndex(key), rs.dive, validErrs, visiting)
+		}
+	}
+}
+
+// visit identifies a pointer followed while walking a value.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// visitPointers marks the pointers leading to the value held by rv as
+// followed.
+func visitPointers(rv reflect.Value, visiting map[visit]bool) map[visit]bool {
+	for ; rv.Kind() == reflect.Ptr && !rv.IsNil(); rv = rv.Elem() {
+		visiting[visit{rv.Pointer(), rv.Type()}] = true
+	}
+	return visiting
+}
+
+// sortedKeys returns the keys of a map in a stable order so that
+// repeated validations report the same errors.
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
+
+// indirect dereferences pointers and interfaces until it reaches
+// a concrete value or a nil one.
+func indirect(rv reflect.Value) reflect.Value {
+	for (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	return rv
+}
+
+// siblingField returns the field named name of the struct parent, for
+// rules comparing fields.
+func siblingField(parent reflect.Value, name string) (reflect.Value, error) {
+	if parent.Kind() != reflect.Struct || name == "" {
+		return reflect.Value{}, ErrBadParameter
+	}
+	f, ok := parent.Type().FieldByName(name)
+	if !ok || f.PkgPath != "" {
+		return reflect.Value{}, ErrBadParameter
+	}
+	return parent.FieldByIndex(f.Index), nil
+}
+
+// valueInterface returns the value held by v, or nil for the zero Value.
+func valueInterface(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// fieldChanged reports whether two values of the same struct field differ.
+// Unexported fields can't be compared and are reported as unchanged.
+func fieldChanged(a, b reflect.Value) bool {
+	if !a.CanInterface() || !b.CanInterface() {
+		return false
+	}
+	return !reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// validateRules runs rules on value and returns the first error. It
+// also returns the value the rules ended up validating, which differs
+// from value for resolved types or after required.
+func (d *Validator) validateRules(ctx context.Context, parent reflect.Value, field reflect.StructField, value reflect.Value, rules []rule) (reflect.Value, error) {
+	value, err := d.resolve(value)
+	if err != nil {
+		return value, err
+	}
+	value = d.normalize(value)
+	for _, rule := range rules {
+		ruleName, ruleValue := rule.name, rule.param
+		var err error
+		if ruleName == "default" {
+			err = setDefault(value, ruleValue)
+		} else if fn, ok := d.ctxFuncs[ruleName]; ok {
+			err = fn(ctx, valueInterface(value), ruleValue)
+		} else if fn, ok := d.fieldFuncs[ruleName]; ok {
+			var other reflect.Value
+			if other, err = siblingField(parent, ruleValue); err == nil {
+				err = fn(valueInterface(value), valueInterface(d.normalize(other)))
+			}
+		} else if fn, ok := d.validateFuncs[ruleName]; ok {
+			err = fn(valueInterface(value), ruleValue)
+		} else if d.strict && !directives[ruleName] {
+			err = ErrUnknownTag
 		}
 
 		if err != nil {
@@ -159,13 +693,19 @@ func (d *Validator) validateField(field reflect.StructField, value reflect.Value
 				if strings.Contains(definedErrStr, `%`) {
 					definedErrStr = fmt.Sprintf(definedErrStr, ruleValue)
 				}
-				return errors.New(definedErrStr)
+				return value, errors.New(definedErrStr)
 			}
-			return err
+			return value, err
+		}
+
+		// once a value is known to be present the rules after
+		// required validate what it points to
+		if ruleName == "required" {
+			value = indirect(value)
 		}
 	}
 
-	return nil
+	return value, nil
 }
 
 func nonzero(v interface{}, param string) error {
@@ -176,8 +716,15 @@ func nonzero(v interface{}, param string) error {
 		valid = utf8.RuneCountInString(st.String()) != 0
 	case reflect.Ptr, reflect.Interface:
 		valid = !st.IsNil()
-	case reflect.Slice, reflect.Map, reflect.Array:
+		if valid && st.Kind() == reflect.Ptr && isBigType(st.Type().Elem()) {
+			valid = bigSign(st.Elem()) != 0
+		}
+	case reflect.Slice, reflect.Map:
 		valid = st.Len() != 0
+	case reflect.Array:
+		// fixed size arrays such as uuid.UUID are zero when all
+		// their elements are
+		valid = !st.IsZero()
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		valid = st.Int() != 0
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
@@ -189,7 +736,12 @@ func nonzero(v interface{}, param string) error {
 	case reflect.Invalid:
 		valid = false
 	case reflect.Struct:
-		valid = true
+		if st.Type() == timeType {
+			valid = !st.Interface().(time.Time).IsZero()
+		}
+		if isBigType(st.Type()) {
+			valid = bigSign(st) != 0
+		}
 	default:
 	}
 
@@ -203,6 +755,11 @@ func nonzero(v interface{}, param string) error {
 // value. For strings it tests the number of characters whereas
 // for maps and slices it tests the number of items.
 func length(v interface{}, param string) error {
+	return lengthOf(v, param, utf8.RuneCountInString)
+}
+
+// lengthOf is length with strLen counting the characters of strings.
+func lengthOf(v interface{}, param string, strLen func(string) int) error {
 	st := reflect.ValueOf(v)
 	valid := true
 	if st.Kind() == reflect.Ptr {
@@ -217,7 +774,7 @@ func length(v interface{}, param string) error {
 		if err != nil {
 			return ErrBadParameter
 		}
-		valid = int64(utf8.RuneCountInString(st.String())) == p
+		valid = int64(strLen(st.String())) == p
 	case reflect.Slice, reflect.Map, reflect.Array:
 		p, err := asInt(param)
 		if err != nil {
@@ -225,7 +782,7 @@ func length(v interface{}, param string) error {
 		}
 		valid = int64(st.Len()) == p
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		p, err := asInt(param)
+		p, err := asIntOf(st.Type(), param)
 		if err != nil {
 			return ErrBadParameter
 		}
@@ -256,6 +813,11 @@ func length(v interface{}, param string) error {
 // strings it tests the number of characters whereas for maps
 // and slices it tests the number of items.
 func min(v interface{}, param string) error {
+	return minOf(v, param, utf8.RuneCountInString)
+}
+
+// minOf is min with strLen counting the characters of strings.
+func minOf(v interface{}, param string, strLen func(string) int) error {
 	st := reflect.ValueOf(v)
 	invalid := false
 	if st.Kind() == reflect.Ptr {
@@ -270,7 +832,7 @@ func min(v interface{}, param string) error {
 		if err != nil {
 			return ErrBadParameter
 		}
-		invalid = int64(utf8.RuneCountInString(st.String())) < p
+		invalid = int64(strLen(st.String())) < p
 	case reflect.Slice, reflect.Map, reflect.Array:
 		p, err := asInt(param)
 		if err != nil {
@@ -278,7 +840,7 @@ func min(v interface{}, param string) error {
 		}
 		invalid = int64(st.Len()) < p
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		p, err := asInt(param)
+		p, err := asIntOf(st.Type(), param)
 		if err != nil {
 			return ErrBadParameter
 		}
@@ -295,6 +857,12 @@ func min(v interface{}, param string) error {
 			return ErrBadParameter
 		}
 		invalid = st.Float() < p
+	case reflect.Struct:
+		c, err := compareStruct(st, param)
+		if err != nil {
+			return err
+		}
+		invalid = c < 0
 	default:
 		return ErrUnsupported
 	}
@@ -309,6 +877,11 @@ func min(v interface{}, param string) error {
 // strings it tests the number of characters whereas for maps
 // and slices it tests the number of items.
 func max(v interface{}, param string) error {
+	return maxOf(v, param, utf8.RuneCountInString)
+}
+
+// maxOf is max with strLen counting the characters of strings.
+func maxOf(v interface{}, param string, strLen func(string) int) error {
 	st := reflect.ValueOf(v)
 	var invalid bool
 	if st.Kind() == reflect.Ptr {
@@ -323,7 +896,7 @@ func max(v interface{}, param string) error {
 		if err != nil {
 			return ErrBadParameter
 		}
-		invalid = int64(utf8.RuneCountInString(st.String())) > p
+		invalid = int64(strLen(st.String())) > p
 	case reflect.Slice, reflect.Map, reflect.Array:
 		p, err := asInt(param)
 		if err != nil {
@@ -331,7 +904,7 @@ func max(v interface{}, param string) error {
 		}
 		invalid = int64(st.Len()) > p
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		p, err := asInt(param)
+		p, err := asIntOf(st.Type(), param)
 		if err != nil {
 			return ErrBadParameter
 		}
@@ -348,6 +921,12 @@ func max(v interface{}, param string) error {
 			return ErrBadParameter
 		}
 		invalid = st.Float() > p
+	case reflect.Struct:
+		c, err := compareStruct(st, param)
+		if err != nil {
+			return err
+		}
+		invalid = c > 0
 	default:
 		return ErrUnsupported
 	}
@@ -357,9 +936,88 @@ func max(v interface{}, param string) error {
 	return nil
 }
 
+// inRange tests whether a variable value is within the bounds given
+// as "min,max", both inclusive. It checks the same way min and max do
+// but reports a single error.
+func inRange(v interface{}, param string) error {
+	return inRangeOf(v, param, utf8.RuneCountInString)
+}
+
+// inRangeOf is inRange with strLen counting the characters of strings.
+func inRangeOf(v interface{}, param string, strLen func(string) int) error {
+	bounds := SplitParams(param)
+	if len(bounds) != 2 {
+		return ErrBadParameter
+	}
+	for _, err := range []error{minOf(v, bounds[0], strLen), maxOf(v, bounds[1], strLen)} {
+		if err == ErrMin || err == ErrMax {
+			return ErrRange
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eq tests whether a variable value is equal to a given value. For
+// strings it compares the content, for numbers the numeric value and
+// for times the instant they represent.
+func eq(v interface{}, param string) error {
+	st := reflect.ValueOf(v)
+	valid := true
+	if st.Kind() == reflect.Ptr {
+		if st.IsNil() {
+			return nil
+		}
+		st = st.Elem()
+	}
+	switch st.Kind() {
+	case reflect.String:
+		valid = st.String() == param
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		p, err := asIntOf(st.Type(), param)
+		if err != nil {
+			return ErrBadParameter
+		}
+		valid = st.Int() == p
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		p, err := asUint(param)
+		if err != nil {
+			return ErrBadParameter
+		}
+		valid = st.Uint() == p
+	case reflect.Float32, reflect.Float64:
+		p, err := asFloat(param)
+		if err != nil {
+			return ErrBadParameter
+		}
+		valid = st.Float() == p
+	case reflect.Bool:
+		p, err := strconv.ParseBool(param)
+		if err != nil {
+			return ErrBadParameter
+		}
+		valid = st.Bool() == p
+	case reflect.Struct:
+		c, err := compareStruct(st, param)
+		if err != nil {
+			return err
+		}
+		valid = c == 0
+	default:
+		return ErrUnsupported
+	}
+	if !valid {
+		return ErrEq
+	}
+	return nil
+}
+
 // regex is the builtin validation function that checks
-// whether the string variable matches a regular expression
-func regex(v interface{}, param string) error {
+// whether the string variable matches a regular expression.
+// A parameter like @slug refers to a pattern added by SetPattern.
+func (d *Validator) regex(v interface{}, param string) error {
 	s, ok := v.(string)
 	if !ok {
 		sptr, ok := v.(*string)
@@ -372,9 +1030,16 @@ func regex(v interface{}, param string) error {
 		s = *sptr
 	}
 
-	re, err := regexp.Compile(param)
-	if err != nil {
-		return ErrBadParameter
+	var re *regexp.Regexp
+	if strings.HasPrefix(param, "@") {
+		if re, ok = d.patterns[param[1:]]; !ok {
+			return ErrBadParameter
+		}
+	} else {
+		var err error
+		if re, err = regexp.Compile(param); err != nil {
+			return ErrBadParameter
+		}
 	}
 
 	if !re.MatchString(s) {
@@ -383,6 +1048,23 @@ func regex(v interface{}, param string) error {
 	return nil
 }
 
+// stringValue returns the string held by a string or *string. ok is false
+// for nil pointers, which rules skip, and err is ErrUnsupported for any
+// other type.
+func stringValue(v interface{}) (s string, ok bool, err error) {
+	st := reflect.ValueOf(v)
+	if st.Kind() == reflect.Ptr {
+		if st.IsNil() {
+			return "", false, nil
+		}
+		st = st.Elem()
+	}
+	if st.Kind() != reflect.String {
+		return "", false, ErrUnsupported
+	}
+	return st.String(), true, nil
+}
+
 // asInt retuns the parameter as a int64
 // or panics if it can't convert
 func asInt(param string) (int64, error) {
@@ -515,9 +1197,24 @@ func nonnil(v interface{}, param string) error {
 	return nil
 }
 
-func enum(v interface{}, param string) error {
-	items := strings.Split(param, ",")
+// required validates that the given value is present: pointers,
+// interfaces, maps and slices must not be nil. Unlike nonzero it
+// accepts present zero values such as a pointer to 0.
+func required(v interface{}, param string) error {
+	st := reflect.ValueOf(v)
+	switch st.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		if st.IsNil() {
+			return ErrRequired
+		}
+	case reflect.Invalid:
+		return ErrRequired
+	}
+	return nil
+}
 
+// enumOf tests whether v is one of items, the values of enum.
+func enumOf(v interface{}, items []string) error {
 	st := reflect.ValueOf(v)
 	invalid := false
 	if st.Kind() == reflect.Ptr {
@@ -551,6 +1248,21 @@ func enum(v interface{}, param string) error {
 			return ErrBadParameter
 		}
 		invalid = !inFloatSlice(st.Float(), p)
+	case reflect.Struct:
+		if !isBigType(st.Type()) {
+			return ErrUnsupported
+		}
+		invalid = true
+		for _, item := range items {
+			c, err := compareBig(st, item)
+			if err != nil {
+				return err
+			}
+			if c == 0 {
+				invalid = false
+				break
+			}
+		}
 	default:
 		return ErrUnsupported
 	}
@@ -559,3 +1271,18 @@ func enum(v interface{}, param string) error {
 	}
 	return nil
 }
+
+// enumciOf tests whether v is one of items the way enumci compares them.
+func enumciOf(v interface{}, items []string) error {
+	s, ok, err := stringValue(v)
+	if !ok {
+		return err
+	}
+	s = strings.TrimSpace(s)
+	for _, item := range items {
+		if strings.EqualFold(s, item) {
+			return nil
+		}
+	}
+	return ErrEnum
+}
@@ -1,7 +1,10 @@
 package govalidator
 
 import (
+	"database/sql"
 	"errors"
+	"reflect"
+	"regexp"
 	"testing"
 )
 
@@ -38,3 +41,369 @@ func TestValidate(t *testing.T) {
 func mycheck(v interface{}, p string) error {
 	return errors.New("mycheck error")
 }
+
+type Profile struct {
+	Name  string `valid:"nonzero"`
+	Email string `valid:"regex=^.+@.+$"`
+	Age   int    `valid:"max=150"`
+}
+
+func TestValidateChanged(t *testing.T) {
+	v := newTestValidator()
+
+	// legacy row with an invalid Email that the update doesn't touch
+	old := Profile{Name: "icepigss", Email: "legacy", Age: 20}
+	upd := old
+	upd.Age = 21
+
+	resp, checked, err := v.ValidateChanged(old, &upd)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(resp) != 0 || len(checked) != 1 || checked[0] != "Age" {
+		t.Fatalf("resp: %v, checked: %v", resp, checked)
+	}
+
+	upd.Age = 200
+	upd.Name = ""
+	resp, checked, _ = v.ValidateChanged(old, upd)
+	if len(checked) != 2 || resp["Name"] != ErrZeroValue || resp["Age"] != ErrMax {
+		t.Fatalf("resp: %v, checked: %v", resp, checked)
+	}
+
+	resp, checked, _ = v.ValidateChanged(nil, old)
+	if len(checked) != 3 || resp["Email"] != ErrRegexp {
+		t.Fatalf("resp: %v, checked: %v", resp, checked)
+	}
+	resp, checked, err = v.ValidateChanged((*Profile)(nil), old)
+	if err != nil || len(checked) != 3 || resp["Email"] != ErrRegexp {
+		t.Fatalf("resp: %v, checked: %v, err: %v", resp, checked, err)
+	}
+
+	if _, _, err := v.ValidateChanged(User{}, old); err != ErrTypeMismatch {
+		t.Fatalf("expected ErrTypeMismatch, got %v", err)
+	}
+}
+
+// newTestValidator returns a validator using the default "valid" tag
+// that doesn't share settings with the package level one.
+func newTestValidator() *Validator {
+	return &Validator{tagName: "valid", validateFuncs: defaultValidator.validateFuncs, errMap: map[string]ErrRuleMap{}}
+}
+
+type Settings struct {
+	Limit   *int           `valid:"required;max=10"`
+	Retries *int           `valid:"max=3"`
+	Offset  *int           `valid:"required;nonzero"`
+	Labels  map[string]int `valid:"required"`
+	Owner   sql.NullString `valid:"required;nonzero"`
+}
+
+func TestRequired(t *testing.T) {
+	v := newTestValidator()
+
+	resp, _ := v.Validate(Settings{})
+	if len(resp) != 4 || resp["Limit"] != ErrRequired || resp["Offset"] != ErrRequired ||
+		resp["Labels"] != ErrRequired || resp["Owner"] != ErrRequired {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	limit, zero := 11, 0
+	resp, _ = v.Validate(Settings{
+		Limit:  &limit,
+		Offset: &zero,
+		Labels: map[string]int{},
+		Owner:  sql.NullString{Valid: true},
+	})
+	if len(resp) != 3 || resp["Limit"] != ErrMax || resp["Offset"] != ErrZeroValue || resp["Owner"] != ErrZeroValue {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+type Signup struct {
+	Login string `valid:"username"`
+	Nick  string `valid:"username;max=8"`
+}
+
+func TestSetAlias(t *testing.T) {
+	v := newTestValidator()
+	v.SetAlias("username", "nonzero;min=3;max=32;regex=^[a-z0-9_]+$")
+	v.SetAlias("loop", "loop;nonzero")
+
+	resp, _ := v.Validate(Signup{Login: "ice_pig", Nick: "icepigss1"})
+	if len(resp) != 1 || resp["Nick"] != ErrMax {
+		t.Fatalf("resp: %v", resp)
+	}
+	resp, _ = v.Validate(Signup{Login: "Ice", Nick: "ab"})
+	if resp["Login"] != ErrRegexp || resp["Nick"] != ErrMin {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	if rules := v.parseTag("loop").rules; len(rules) != maxAliasDepth+1 {
+		t.Fatalf("rules: %v", rules)
+	}
+
+	v.SetAlias("username", "")
+	resp, _ = v.Validate(Signup{Login: "Ice"})
+	if len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+type Article struct {
+	Slug string  `valid:"regex=@slug"`
+	Lang *string `valid:"regex=@lang"`
+}
+
+func TestSetPattern(t *testing.T) {
+	v := New()
+	v.SetPattern("slug", regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`))
+
+	resp, _ := v.Validate(Article{Slug: "hello-world"})
+	if len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+	lang := "en"
+	resp, _ = v.Validate(Article{Slug: "Hello World", Lang: &lang})
+	if resp["Slug"] != ErrRegexp || resp["Lang"] != ErrBadParameter {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func TestRange(t *testing.T) {
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{5, "5,100", nil},
+		{100, "5, 100", nil},
+		{4, "5,100", ErrRange},
+		{101, "5,100", ErrRange},
+		{"abcd", "2,3", ErrRange},
+		{[]int{1, 2}, "1,3", nil},
+		{0.5, "0,1", nil},
+		{5, "5", ErrBadParameter},
+		{5, "a,b", ErrBadParameter},
+		{true, "0,1", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := inRange(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+}
+
+type Post struct {
+	Tags   []string          `valid:"min=1;dive;nonzero;max=5"`
+	Grid   [][]string        `valid:"max=2;dive;min=1;dive;len=1"`
+	Scores map[string]int    `valid:"dive;max=100"`
+	Refs   *[]string         `valid:"dive;nonzero"`
+	Meta   map[string]string `valid:"nonzero"`
+}
+
+func TestDive(t *testing.T) {
+	v := New()
+
+	resp, _ := v.Validate(Post{
+		Tags:   []string{"go", "", "validator"},
+		Grid:   [][]string{{"a", "bb"}, {}},
+		Scores: map[string]int{"a": 10, "b": 101},
+	})
+	want := Error{
+		"Tags[1]":    ErrZeroValue,
+		"Tags[2]":    ErrMax,
+		"Grid[0][1]": ErrLen,
+		"Grid[1]":    ErrMin,
+		"Scores[b]":  ErrMax,
+		"Meta":       ErrZeroValue,
+	}
+	if !reflect.DeepEqual(resp, want) {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	resp, _ = v.Validate(Post{Grid: [][]string{{"a"}, {"b"}, {"c"}}, Meta: map[string]string{"k": "v"}})
+	if !reflect.DeepEqual(resp, Error{"Tags": ErrMin, "Grid": ErrMax}) {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+type Service struct {
+	Links map[string]string `valid:"max=4;dive;keys;regex=^[a-z0-9]+$;endkeys;regex=^https://"`
+}
+
+func TestDiveKeys(t *testing.T) {
+	resp, _ := New().Validate(Service{Links: map[string]string{
+		"docs":  "https://example.com/docs",
+		"Home":  "https://example.com",
+		"repo":  "ftp://example.com",
+		"ci_cd": "",
+	}})
+	want := Error{"Links[Home]": ErrRegexp, "Links[ci_cd]": ErrRegexp, "Links[repo]": ErrRegexp}
+	if !reflect.DeepEqual(resp, want) {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+type Address struct {
+	City string `valid:"nonzero"`
+	Zip  string `valid:"len=5"`
+}
+
+type Period struct {
+	From int
+	To   int `valid:"min=1"`
+}
+
+func (p *Period) Validate() error {
+	if p.To < p.From {
+		return errors.New("period ends before it starts")
+	}
+	return nil
+}
+
+type Company struct {
+	Name     string
+	HQ       Address
+	Branch   *Address `valid:"required"`
+	Billing  *Address `valid:"structonly;required"`
+	Open     Period
+	Trial    Period    `valid:"nostructlevel"`
+	Offices  []Address `valid:"dive"`
+	internal Address   `valid:"nonzero"`
+}
+
+func (c Company) Validate() error {
+	if c.Name == "" {
+		return errors.New("company without a name")
+	}
+	return nil
+}
+
+func TestNestedStructs(t *testing.T) {
+	resp, _ := New().Validate(Company{
+		HQ:      Address{City: "Berlin", Zip: "101"},
+		Billing: &Address{},
+		Open:    Period{From: 5, To: 3},
+		Trial:   Period{From: 5, To: 3},
+		Offices: []Address{{City: "Paris", Zip: "75001"}, {Zip: "75002"}},
+	})
+	want := Error{
+		"HQ.Zip":          ErrLen,
+		"Branch":          ErrRequired,
+		"Open":            errors.New("period ends before it starts"),
+		"Offices[1].City": ErrZeroValue,
+		"internal":        ErrCannotValidate,
+	}
+	if !reflect.DeepEqual(resp, want) {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+type Node struct {
+	Name     string `valid:"nonzero"`
+	Next     *Node
+	Children []*Node `valid:"dive"`
+}
+
+// Schedule validates itself through Validate, as its hook isn't run for
+// the struct being validated.
+type Schedule struct {
+	Open Period
+	Days int `valid:"min=1"`
+}
+
+func (s Schedule) Validate() error {
+	resp, err := Validate(s)
+	if err != nil || len(resp) != 0 {
+		return errors.New("invalid schedule")
+	}
+	return nil
+}
+
+func TestStructCycles(t *testing.T) {
+	n := &Node{}
+	n.Next = n
+	n.Children = []*Node{n, {Name: "leaf", Next: n}}
+	resp, _ := New().Validate(n)
+	want := Error{"Name": ErrZeroValue}
+	if !reflect.DeepEqual(resp, want) {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	a, b := &Node{Name: "a"}, &Node{}
+	a.Next, b.Next = b, a
+	resp, _ = New().Validate(*a)
+	if len(resp) != 1 || resp["Next.Name"] != ErrZeroValue {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	if err := (Schedule{Days: 1}).Validate(); err != nil {
+		t.Fatalf("valid: %v", err)
+	}
+	if err := (Schedule{Open: Period{From: 2, To: 1}, Days: 1}).Validate(); err == nil {
+		t.Fatal("invalid schedule passed")
+	}
+}
+
+type Legacy struct {
+	Name string `valid:"NONZERO;Min=3"`
+	Code string `valid:"min=2;lenght=4"`
+}
+
+func TestIgnoreCaseAndStrict(t *testing.T) {
+	v := New()
+	resp, _ := v.Validate(Legacy{Code: "ab"})
+	if len(resp) != 0 {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	v.SetIgnoreCase(true)
+	resp, _ = v.Validate(Legacy{Name: "ab", Code: "ab"})
+	if len(resp) != 1 || resp["Name"] != ErrMin {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	v.SetStrict(true)
+	resp, _ = v.Validate(Legacy{Name: "abc", Code: "ab"})
+	if len(resp) != 1 || resp["Code"] != ErrUnknownTag {
+		t.Fatalf("resp: %v", resp)
+	}
+
+	// names only differing in case resolve to the first in sort order
+	v.SetFunc("Short", func(v interface{}, param string) error { return ErrMax })
+	v.SetFunc("short", func(v interface{}, param string) error { return nil })
+	for i := 0; i < 20; i++ {
+		if got := v.ruleName("SHORT"); got != "Short" {
+			t.Fatalf("ruleName: %s", got)
+		}
+	}
+
+	v.SetIgnoreCase(false)
+	resp, _ = v.Validate(Legacy{Name: "abc", Code: "abcd"})
+	if resp["Name"] != ErrUnknownTag || resp["Code"] != ErrUnknownTag {
+		t.Fatalf("resp: %v", resp)
+	}
+}
+
+func TestEnumCI(t *testing.T) {
+	green := " GREEN\n"
+	tests := []struct {
+		v   interface{}
+		err error
+	}{
+		{"Red", nil},
+		{"red", nil},
+		{&green, nil},
+		{"purple", ErrEnum},
+		{"", ErrEnum},
+		{(*string)(nil), nil},
+		{1, ErrUnsupported},
+	}
+	d := New()
+	for i, tt := range tests {
+		if err := d.enumci(tt.v, "Red, Green,Blue"); !errors.Is(err, tt.err) {
+			t.Errorf("%d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package govalidator
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrXML    = errors.New("invalid xml")
+	ErrXMLDTD = errors.New("xml dtd not allowed")
+)
+
+// defaultXMLDepth bounds the nesting of elements xml accepts.
+const defaultXMLDepth = 256
+
+// isXML tests whether a string or []byte is a well-formed XML document:
+// one root element, properly nested and closed, and only the predefined
+// entities. Document type declarations may not declare entities, so that
+// nothing gets expanded. The parameters are:
+//
+//	nodtd    no document type declaration at all
+//	depth:N  elements nest at most N deep, 256 by default
+func isXML(v interface{}, param string) error {
+	b, err := jsonBytes(v)
+	if err != nil || len(b) == 0 {
+		return err
+	}
+	noDTD, maxDepth := false, defaultXMLDepth
+	for _, p := range SplitParams(param) {
+		switch {
+		case p == "nodtd":
+			noDTD = true
+		case strings.HasPrefix(p, "depth:"):
+			if maxDepth, err = strconv.Atoi(p[len("depth:"):]); err != nil || maxDepth <= 0 {
+				return ErrBadParameter
+			}
+		default:
+			return ErrBadParameter
+		}
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(b))
+	depth, roots := 0, 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ErrXML
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				roots++
+			}
+			if depth++; depth > maxDepth || roots > 1 {
+				return ErrXML
+			}
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if depth == 0 && len(bytes.TrimSpace(t)) > 0 {
+				return ErrXML
+			}
+		case xml.Directive:
+			if noDTD || bytes.Contains(t, []byte("<!ENTITY")) {
+				return ErrXMLDTD
+			}
+		}
+	}
+	if roots != 1 {
+		return ErrXML
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package govalidator
+
+import (
+	"strings"
+	"testing"
+)
+
+type Envelope struct {
+	Body string `valid:"xml=nodtd"`
+}
+
+func TestXML(t *testing.T) {
+	bomb := `<?xml version="1.0"?><!DOCTYPE lolz [<!ENTITY lol "lol"><!ENTITY lol2 "&lol;&lol;">]><lolz>&lol2;</lolz>`
+	tests := []struct {
+		v     interface{}
+		param string
+		err   error
+	}{
+		{`<?xml version="1.0"?><order id="1"><item>a &amp; b</item></order>`, "", nil},
+		{"<a/>", "", nil},
+		{"\n<!-- note --><a><b/></a>\n", "", nil},
+		{[]byte("<a><![CDATA[<raw>]]></a>"), "", nil},
+		{`<!DOCTYPE note SYSTEM "note.dtd"><note/>`, "", nil},
+		{`<!DOCTYPE note SYSTEM "note.dtd"><note/>`, "nodtd", ErrXMLDTD},
+		{bomb, "", ErrXMLDTD},
+		{"<a><b></a></b>", "", ErrXML},
+		{"<a>", "", ErrXML},
+		{"<a/><b/>", "", ErrXML},
+		{"text<a/>", "", ErrXML},
+		{"<a>&nbsp;</a>", "", ErrXML},
+		{"<a x=1/>", "", ErrXML},
+		{"just text", "", ErrXML},
+		{"<!-- only a comment -->", "", ErrXML},
+		{strings.Repeat("<a>", 5) + strings.Repeat("</a>", 5), "depth:5", nil},
+		{strings.Repeat("<a>", 6) + strings.Repeat("</a>", 6), "depth:5", ErrXML},
+		{strings.Repeat("<a>", 300) + strings.Repeat("</a>", 300), "", ErrXML},
+		{"", "", nil},
+		{"<a/>", "depth:0", ErrBadParameter},
+		{"<a/>", "strict", ErrBadParameter},
+		{1, "", ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := isXML(tt.v, tt.param); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+
+	resp, _ := New().Validate(Envelope{Body: bomb})
+	if resp["Body"] != ErrXMLDTD {
+		t.Fatalf("resp: %v", resp)
+	}
+}
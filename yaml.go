@@ -0,0 +1,34 @@
+//go:build govalidator_yaml
+
+package govalidator
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+var yamlRule ValidateFunc = isYAML
+
+// isYAML tests whether a string or []byte is well-formed YAML, every
+// document of a multi-document stream. It is only built with the
+// govalidator_yaml tag, which adds a dependency on gopkg.in/yaml.v3.
+func isYAML(v interface{}, param string) error {
+	b, err := jsonBytes(v)
+	if err != nil || len(b) == 0 {
+		return err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	for {
+		var doc yaml.Node
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return ErrYAML
+		}
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !govalidator_yaml
+
+package govalidator
+
+// yamlRule is nil unless built with the govalidator_yaml tag, leaving the
+// yaml rule unknown.
+var yamlRule ValidateFunc
@@ -0,0 +1,30 @@
+//go:build govalidator_yaml
+
+package govalidator
+
+import "testing"
+
+func TestYAML(t *testing.T) {
+	tests := []struct {
+		v   interface{}
+		err error
+	}{
+		{"name: app\nreplicas: 3\n", nil},
+		{"- a\n- b\n", nil},
+		{"a: 1\n---\nb: 2\n", nil},
+		{[]byte("key: [1, 2]"), nil},
+		{"a: 1\n---\nb: [2\n", ErrYAML},
+		{"key: value\n  bad: indent", ErrYAML},
+		{"\tkey: tab", ErrYAML},
+		{"", nil},
+		{1, ErrUnsupported},
+	}
+	for i, tt := range tests {
+		if err := isYAML(tt.v, ""); err != tt.err {
+			t.Errorf("%d: %v: expected %v, got %v", i, tt.v, tt.err, err)
+		}
+	}
+	if _, ok := New().validateFuncs["yaml"]; !ok {
+		t.Fatal("yaml rule not registered")
+	}
+}